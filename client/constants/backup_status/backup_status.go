@@ -0,0 +1,7 @@
+package backup_status
+
+const (
+	IN_PROGRESS = "IN_PROGRESS"
+	COMPLETED   = "COMPLETED"
+	FAILED      = "FAILED"
+)
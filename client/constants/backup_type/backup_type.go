@@ -0,0 +1,13 @@
+package backup_type
+
+const (
+	ON_DEMAND = "ON_DEMAND"
+	SCHEDULED = "SCHEDULED"
+)
+
+func GetAll() []string {
+	return []string{
+		ON_DEMAND,
+		SCHEDULED,
+	}
+}
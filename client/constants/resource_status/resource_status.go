@@ -0,0 +1,6 @@
+package resource_status
+
+const (
+	READY  = "READY"
+	FAILED = "FAILED"
+)
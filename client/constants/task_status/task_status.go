@@ -0,0 +1,18 @@
+package task_status
+
+const (
+	QUEUED      = "QUEUED"
+	IN_PROGRESS = "IN_PROGRESS"
+	SUCCESS     = "SUCCESS"
+	FAILED      = "FAILED"
+	CANCELLED   = "CANCELLED"
+)
+
+// IsTerminal - Tells whether a task in the given status will not change anymore
+func IsTerminal(status string) bool {
+	switch status {
+	case SUCCESS, FAILED, CANCELLED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,6 @@
+package upgrade_request_type
+
+const (
+	SERVICE    = "SERVICE"
+	DATA_PLANE = "DATA_PLANE"
+)
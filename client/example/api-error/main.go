@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
@@ -10,6 +11,7 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
 	mdsHost := "MDS_HOST_URL"
 	client, err := mds.NewClient(&mdsHost, &model.ClientAuth{
 		ApiToken:     "API_TOKEN",
@@ -20,7 +22,7 @@ func main() {
 		return
 	}
 
-	_, err = client.Controller.GetMdsCluster("12376yhsjdasd")
+	_, err = client.Controller.GetMdsCluster(ctx, "12376yhsjdasd")
 
 	if err != nil {
 		fmt.Println(err)
@@ -29,6 +31,12 @@ func main() {
 			fmt.Println("recognized")
 			fmt.Println(apiError.ErrorMessage)
 		}
+		if core.IsNotFound(err) {
+			fmt.Println("cluster does not exist")
+		}
+		if errorCode, ok := core.LookupErrorCode(core.ErrorCodeOf(err)); ok {
+			fmt.Printf("%s error: %s\n", errorCode.Service, errorCode.Description)
+		}
 		return
 	}
 }
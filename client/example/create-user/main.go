@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/account_type"
@@ -12,6 +13,7 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
 	mdsHost := "MDS_HOST_URL"
 	client, err := mds.NewClient(&mdsHost, &model.ClientAuth{
 		ApiToken:     "API_TOKEN",
@@ -22,7 +24,7 @@ func main() {
 		return
 	}
 
-	err = client.CustomerMetadata.CreateMdsUser(&customer_metadata.MdsCreateUserRequest{
+	err = client.CustomerMetadata.CreateMdsUser(ctx, &customer_metadata.MdsCreateUserRequest{
 		AccountType: account_type.USER_ACCOUNT,
 		Usernames:   []string{"developer@vmware.com"},
 		PolicyIds:   []string{"6446112a8710fc120cbdc8ff", "6438cbd364740d4d48dc2673"},
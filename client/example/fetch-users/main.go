@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/account_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
@@ -10,6 +11,7 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
 	mdsHost := "MDS_HOST_URL"
 	client, err := mds.NewClient(&mdsHost, &model.ClientAuth{
 		ApiToken:     "API_TOKEN",
@@ -20,7 +22,7 @@ func main() {
 		return
 	}
 
-	response, err := client.CustomerMetadata.GetMdsUsers(&customer_metadata.MdsUsersQuery{
+	response, err := client.CustomerMetadata.GetMdsUsers(ctx, &customer_metadata.MdsUsersQuery{
 		AccountType: account_type.USER_ACCOUNT,
 		Emails:      []string{"admin@vmware.com", "developer@vmware.com"},
 	})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
@@ -11,6 +12,7 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
 	mdsHost := "MDS_HOST_URL"
 	client, err := mds.NewClient(&mdsHost, &model.ClientAuth{
 		ApiToken:     "API_TOKEN",
@@ -21,7 +23,7 @@ func main() {
 		return
 	}
 
-	err = client.CustomerMetadata.UpdateMdsUser("64533d8a2cee5b76e7c5fa70", &customer_metadata.MdsUserUpdateRequest{
+	err = client.CustomerMetadata.UpdateMdsUser(ctx, "64533d8a2cee5b76e7c5fa70", &customer_metadata.MdsUserUpdateRequest{
 		//PolicyIds:   []string{"644a14ac4efa951adae6b7d3"},
 		Tags: []string{"client-test"},
 		ServiceRoles: &[]customer_metadata.RolesRequest{
@@ -1,10 +1,12 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"time"
 )
 
 const (
@@ -22,7 +24,7 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 }
 
 // GetAccessToken - Get a new token for user
-func (s *Service) GetAccessToken() (*TokenResponse, error) {
+func (s *Service) GetAccessToken(ctx context.Context) (*TokenResponse, error) {
 	if s.Api.AuthToUse.ApiToken == "" && s.Api.AuthToUse.OAuthAppType == oauth_type.ApiToken {
 		return nil, fmt.Errorf("define API Token")
 	}
@@ -58,7 +60,7 @@ func (s *Service) GetAccessToken() (*TokenResponse, error) {
 	if s.Api.AuthToUse.OAuthAppType == oauth_type.ClientCredentials {
 		s.Api.OrgId = s.Api.AuthToUse.OrgId
 	}
-	body, err := s.Api.Post(&reqUrl, &tokenRequest, nil)
+	body, err := s.Api.Post(core.WithoutAuthentication(ctx), &reqUrl, &tokenRequest, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -76,16 +78,23 @@ func (s *Service) GetAccessToken() (*TokenResponse, error) {
 }
 
 func (s *Service) processAuthResponse(response *TokenResponse) error {
-	s.Api.Token = &response.Token
-	token, err := jwt.Parse(*s.Api.Token, nil)
+	token, err := jwt.Parse(response.Token, nil)
 	if token == nil {
 		return err
 	}
+	claims, _ := token.Claims.(jwt.MapClaims)
 	if s.Api.AuthToUse.OAuthAppType == oauth_type.ApiToken {
-		claims, _ := token.Claims.(jwt.MapClaims)
+		if orgId, ok := claims["context_name"].(string); ok {
+			s.Api.OrgId = orgId
+		}
+	}
 
-		s.Api.OrgId = claims["context_name"].(string)
+	// without an expiry claim the token is kept until MDS rejects it
+	var expiry time.Time
+	if exp, ok := claims["exp"].(float64); ok {
+		expiry = time.Unix(int64(exp), 0)
 	}
+	s.Api.SetToken(response.Token, expiry)
 
 	return nil
 }
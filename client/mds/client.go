@@ -1,18 +1,18 @@
 package mds
 
 import (
-	"crypto/tls"
+	"context"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/auth"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/infra-connector"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/service-metadata"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/task-service"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/upgrade-service"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"net/http"
 	"strings"
-	"time"
 )
 
 // HostURL - Default MDS URL
@@ -27,26 +27,71 @@ type Client struct {
 	CustomerMetadata *customer_metadata.Service
 	ServiceMetadata  *service_metadata.Service
 	UpgradeService   *upgrade_service.Service
+	TaskService      *task_service.Service
+}
+
+// Config - Optional settings of the client, nil fields fall back to defaults
+type Config struct {
+	RetryPolicy *core.RetryPolicy
+	TLS         *TLSConfig
+	Transport   *TransportConfig
 }
 
 // NewClient -
 func NewClient(host *string, authInfo *model.ClientAuth) (*Client, error) {
+	return NewClientWithConfig(host, authInfo, nil)
+}
+
+// NewClientWithConfig - Same as NewClient, with optional settings to tune the underlying HTTP client
+func NewClientWithConfig(host *string, authInfo *model.ClientAuth, config *Config) (*Client, error) {
+	return NewClientWithOptions(host, authInfo, WithConfig(config))
+}
+
+// NewClientWithOptions - Same as NewClient, customised by the given options
+func NewClientWithOptions(host *string, authInfo *model.ClientAuth, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	config := &o.config
+	if config.Transport == nil {
+		config.Transport = &TransportConfig{}
+	}
 	hostUrl := HostURL
-	if len(strings.TrimSpace(*host)) != 0 {
+	if host != nil && len(strings.TrimSpace(*host)) != 0 {
 		hostUrl = *host
 	}
 
-	httpClient := prepareHttpClient()
+	httpClient := o.httpClient
+	if httpClient == nil {
+		var err error
+		if httpClient, err = prepareHttpClient(config); err != nil {
+			return nil, err
+		}
+	}
 	root := &core.Root{
 		// Default MDS URL
-		HostUrl:    &hostUrl,
-		AuthToUse:  authInfo,
-		HttpClient: httpClient,
+		HostUrl:     &hostUrl,
+		AuthToUse:   authInfo,
+		HttpClient:  httpClient,
+		RetryPolicy: config.RetryPolicy,
+
+		RequestTimeout:   valueOrDefault(config.Transport.RequestTimeout, DefaultRequestTimeout),
+		OperationTimeout: valueOrDefault(config.Transport.OperationTimeout, DefaultOperationTimeout),
+		UserAgent:        o.userAgent,
+		Logger:           o.logger,
 	}
 
-	c := prepareClient(host, root)
+	c := prepareClient(&hostUrl, root)
+	root.Authenticate = func(ctx context.Context) error {
+		_, err := c.Auth.GetAccessToken(ctx)
+		return err
+	}
+	if o.lazyAuth {
+		return c, nil
+	}
 
-	_, err := c.Auth.GetAccessToken()
+	_, err := c.Auth.GetAccessToken(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -63,14 +108,21 @@ func prepareClient(host *string, root *core.Root) *Client {
 		CustomerMetadata: customer_metadata.NewService(host, root),
 		ServiceMetadata:  service_metadata.NewService(host, root),
 		UpgradeService:   upgrade_service.NewService(host, root),
+		TaskService:      task_service.NewService(host, root),
 	}
 }
 
-func prepareHttpClient() *http.Client {
-	return &http.Client{
-		Timeout: 60 * time.Minute,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+func prepareHttpClient(config *Config) (*http.Client, error) {
+	tlsConfig, err := prepareTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
 	}
+	transport, err := prepareTransport(config.Transport, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	// timeouts are applied per request by core.Root, as they differ between an attempt and a whole call
+	return &http.Client{
+		Transport: transport,
+	}, nil
 }
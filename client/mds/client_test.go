@@ -0,0 +1,277 @@
+package mds
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tokenHandler stands in for MDS, answering the token endpoint only.
+func tokenHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/authservice/token" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(testToken()))
+}
+
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(tokenHandler))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testToken() string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := encode([]byte(fmt.Sprintf(`{"context_name":"test-org","exp":%d}`, time.Now().Add(time.Hour).Unix())))
+	return header + "." + claims + "." + encode([]byte("signature"))
+}
+
+func newTestClient(server *httptest.Server, config *Config) (*Client, error) {
+	return NewClientWithConfig(&server.URL, &model.ClientAuth{
+		ApiToken:     "api-token",
+		OAuthAppType: oauth_type.ApiToken,
+	}, config)
+}
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestNewClientVerifiesServerCertificate(t *testing.T) {
+	server := newTLSServer(t)
+
+	_, err := newTestClient(server, nil)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("expected certificate verification error, got %v", err)
+	}
+}
+
+func TestNewClientTrustsConfiguredCA(t *testing.T) {
+	server := newTLSServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCAPEM(server)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"pem":  serverCAPEM(server),
+		"file": caFile,
+	}
+	for name, caCert := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := newTestClient(server, &Config{TLS: &TLSConfig{CACert: caCert}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.Root.OrgId != "test-org" {
+				t.Errorf("expected org id from token, got %q", client.Root.OrgId)
+			}
+		})
+	}
+}
+
+func TestNewClientInsecure(t *testing.T) {
+	server := newTLSServer(t)
+
+	if _, err := newTestClient(server, &Config{TLS: &TLSConfig{Insecure: true}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewClientMutualTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(tokenHandler))
+	certPEM, keyPEM, cert := generateClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	if _, err := newTestClient(server, &Config{TLS: &TLSConfig{CACert: serverCAPEM(server)}}); err == nil {
+		t.Fatal("expected handshake error without client certificate")
+	}
+
+	tlsConfig := &TLSConfig{CACert: serverCAPEM(server), ClientCert: certPEM, ClientKey: keyPEM}
+	if _, err := newTestClient(server, &Config{TLS: tlsConfig}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewClientInvalidTLSConfig(t *testing.T) {
+	server := newTLSServer(t)
+	certPEM, _, _ := generateClientCertificate(t)
+	_, otherKeyPEM, _ := generateClientCertificate(t)
+
+	tests := map[string]*TLSConfig{
+		"invalid ca":       {CACert: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----"},
+		"missing ca file":  {CACert: filepath.Join(t.TempDir(), "missing.pem")},
+		"cert without key": {ClientCert: certPEM},
+		"mismatched key":   {ClientCert: certPEM, ClientKey: otherKeyPEM},
+	}
+	for name, tlsConfig := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newTestClient(server, &Config{TLS: tlsConfig}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func generateClientCertificate(t *testing.T) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "mds-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM), cert
+}
+
+func TestNewClientThroughProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.Host)
+		tokenHandler(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	host := "http://mds.example.invalid"
+	_, err := NewClientWithConfig(&host, &model.ClientAuth{
+		ApiToken:     "api-token",
+		OAuthAppType: oauth_type.ApiToken,
+	}, &Config{Transport: &TransportConfig{ProxyURL: proxy.URL}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "mds.example.invalid" {
+		t.Errorf("expected the token request to go through the proxy, got %v", proxied)
+	}
+}
+
+func TestNewClientRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	config := &Config{
+		RetryPolicy: &core.RetryPolicy{MaxRetries: 0, WaitMin: time.Millisecond, WaitMax: time.Millisecond},
+		Transport:   &TransportConfig{RequestTimeout: 50 * time.Millisecond},
+	}
+	start := time.Now()
+	_, err := newTestClient(server, config)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request was not cut by the request timeout, took %s", elapsed)
+	}
+}
+
+func TestNewClientInvalidProxy(t *testing.T) {
+	server := newTLSServer(t)
+
+	if _, err := newTestClient(server, &Config{Transport: &TransportConfig{ProxyURL: "://proxy"}}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	var requests []*http.Request
+	httpClient := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req)
+		recorder := httptest.NewRecorder()
+		if req.URL.Path == "/api/authservice/token" {
+			tokenHandler(recorder, req)
+		} else {
+			_, _ = recorder.WriteString("{}")
+		}
+		return recorder.Result(), nil
+	})}
+	var traces []string
+	logger := core.LoggerFunc(func(_ context.Context, msg string, _ map[string]any) {
+		traces = append(traces, msg)
+	})
+
+	host := "https://mds.example.invalid"
+	client, err := NewClientWithOptions(&host, &model.ClientAuth{
+		ApiToken:     "api-token",
+		OAuthAppType: oauth_type.ApiToken,
+	}, WithHTTPClient(httpClient), WithLogger(logger), WithUserAgent("mds-tool/1.0"), WithLazyAuth())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("expected no request before the first call with lazy auth, got %d", len(requests))
+	}
+
+	reqUrl := host + "/api/controller/clusters"
+	if _, err = client.Root.Get(context.Background(), &reqUrl, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 2 || requests[0].URL.Path != "/api/authservice/token" {
+		t.Fatalf("expected authentication then the call, got %d requests", len(requests))
+	}
+	if agent := requests[1].Header.Get("User-Agent"); agent != "mds-tool/1.0" {
+		t.Errorf("expected user agent to be set, got %q", agent)
+	}
+	if requests[1].Header.Get("csp-auth-token") == "" {
+		t.Error("expected the call to be authenticated")
+	}
+	if len(traces) != 2 {
+		t.Errorf("expected a trace per call, got %v", traces)
+	}
+}
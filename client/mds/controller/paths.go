@@ -10,4 +10,9 @@ const (
 	Dashboard     = "dashboard"
 	NetworkPolicy = "networkpolicy"
 	MetaData      = "metadata"
+	Resize        = "resize"
+	Backups       = "backups"
+	BackupPolicy  = "backup-policy"
+	Maintenance   = "maintenance"
+	Versions      = "versions"
 )
@@ -0,0 +1,8 @@
+package controller
+
+import "github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+
+type MdsClusterBackupsQuery struct {
+	BackupType string `schema:"backupType,omitempty"`
+	model.PageQuery
+}
@@ -0,0 +1,6 @@
+package controller
+
+type MdsServiceVersionsQuery struct {
+	ServiceType string `schema:"serviceType"`
+	Provider    string `schema:"provider,omitempty"`
+}
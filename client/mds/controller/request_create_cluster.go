@@ -1,25 +1,67 @@
 package controller
 
+import "github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+
 type MdsClusterCreateRequest struct {
-	Name              string                  `json:"name"`
-	ServiceType       string                  `json:"serviceType"`
-	Provider          string                  `json:"provider"`
-	InstanceSize      string                  `json:"instanceSize"`
-	Region            string                  `json:"region"`
-	Dedicated         bool                    `json:"dedicated"`
-	Shared            bool                    `json:"shared,omitempty"`
-	Tags              []string                `json:"tags,omitempty"`
-	NetworkPolicyIds  []string                `json:"networkPolicyIds,omitempty"`
-	DataPlaneId       string                  `json:"dataPlaneId,omitempty"`
-	Version           string                  `json:"version"`
-	StoragePolicyName string                  `json:"storagePolicyName"`
-	ClusterMetadata   PostgresClusterMetadata `json:"clusterMetadata"`
+	Name              string          `json:"name"`
+	ServiceType       string          `json:"serviceType"`
+	Provider          string          `json:"provider"`
+	InstanceSize      string          `json:"instanceSize"`
+	Region            string          `json:"region"`
+	Dedicated         bool            `json:"dedicated"`
+	Shared            bool            `json:"shared,omitempty"`
+	Tags              []string        `json:"tags,omitempty"`
+	NetworkPolicyIds  []string        `json:"networkPolicyIds,omitempty"`
+	DataPlaneId       string          `json:"dataPlaneId,omitempty"`
+	Version           string          `json:"version"`
+	StoragePolicyName string          `json:"storagePolicyName"`
+	ClusterMetadata   ClusterMetadata `json:"clusterMetadata,omitempty"`
+}
+
+// ClusterMetadata - Service specific settings of a cluster to create, only the one of its service type is sent
+type ClusterMetadata interface {
+	ServiceType() string
 }
 
 type PostgresClusterMetadata struct {
-	Username    string   `json:"username"`
-	Password    string   `json:"password"`
-	Database    string   `json:"database"`
-	RestoreFrom string   `json:"restore_from"`
-	Extensions  []string `json:"extensions"`
+	Username           string   `json:"username"`
+	Password           string   `json:"password"`
+	Database           string   `json:"database"`
+	RestoreFrom        string   `json:"restore_from,omitempty"`
+	RestorePointInTime string   `json:"restore_point_in_time,omitempty"`
+	Extensions         []string `json:"extensions"`
+}
+
+func (PostgresClusterMetadata) ServiceType() string {
+	return service_type.POSTGRES
+}
+
+type MySqlClusterMetadata struct {
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	Database           string `json:"database"`
+	RestoreFrom        string `json:"restore_from,omitempty"`
+	RestorePointInTime string `json:"restore_point_in_time,omitempty"`
+}
+
+func (MySqlClusterMetadata) ServiceType() string {
+	return service_type.MYSQL
+}
+
+type RedisClusterMetadata struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (RedisClusterMetadata) ServiceType() string {
+	return service_type.REDIS
+}
+
+type RabbitMqClusterMetadata struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (RabbitMqClusterMetadata) ServiceType() string {
+	return service_type.RABBITMQ
 }
@@ -0,0 +1,5 @@
+package controller
+
+type MdsClusterBackupCreateRequest struct {
+	Name string `json:"name,omitempty"`
+}
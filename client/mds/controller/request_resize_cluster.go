@@ -0,0 +1,5 @@
+package controller
+
+type MdsClusterResizeRequest struct {
+	InstanceSize string `json:"instanceSize"`
+}
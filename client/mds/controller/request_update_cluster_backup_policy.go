@@ -0,0 +1,10 @@
+package controller
+
+// MdsClusterBackupPolicyUpdateRequest - Either CronSchedule or DailyWindow sets when the backups are taken
+type MdsClusterBackupPolicyUpdateRequest struct {
+	CronSchedule   string `json:"cronSchedule,omitempty"`
+	DailyWindow    string `json:"dailyWindow,omitempty"`
+	RetentionCount int64  `json:"retentionCount"`
+	RetentionDays  int64  `json:"retentionDays"`
+	PitrEnabled    bool   `json:"pitrEnabled"`
+}
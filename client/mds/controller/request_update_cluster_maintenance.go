@@ -0,0 +1,9 @@
+package controller
+
+// MdsClusterMaintenanceUpdateRequest - The window is given by its next occurrence, in epoch milliseconds,
+// MDS repeats it every week. Zero times remove the window.
+type MdsClusterMaintenanceUpdateRequest struct {
+	MaintenanceStartTime int64 `json:"maintenanceStartTime"`
+	MaintenanceEndTime   int64 `json:"maintenanceEndTime"`
+	PauseUpdates         bool  `json:"pauseUpdates"`
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
@@ -30,7 +31,7 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 }
 
 // GetMdsClusters - Returns page of clusters
-func (s *Service) GetMdsClusters(query *MdsClustersQuery) (model.Paged[model.MdsCluster], error) {
+func (s *Service) GetMdsClusters(ctx context.Context, query *MdsClustersQuery) (model.Paged[model.MdsCluster], error) {
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Clusters)
 	var response model.Paged[model.MdsCluster]
 
@@ -38,7 +39,7 @@ func (s *Service) GetMdsClusters(query *MdsClustersQuery) (model.Paged[model.Mds
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&urlPath, query, &response)
+	_, err := s.Api.Get(ctx, &urlPath, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -46,33 +47,25 @@ func (s *Service) GetMdsClusters(query *MdsClustersQuery) (model.Paged[model.Mds
 	return response, nil
 }
 
-// GetAllMdsClusters - Returns list of all clusters
-func (s *Service) GetAllMdsClusters(query *MdsClustersQuery) ([]model.MdsCluster, error) {
-	var clusters []model.MdsCluster
-	for {
-		queriedClusters, err := s.GetMdsClusters(query)
-		if err != nil {
-			return clusters, err
-		}
-		clusters = append(clusters, *queriedClusters.Get()...)
-		nextPage := utils.GetNextPageInfo(queriedClusters.GetPage())
-		if nextPage == nil {
-			break
-		}
-		query.PageQuery = *nextPage
+// GetAllMdsClusters - Returns the clusters of all pages, see utils.ListAll
+func (s *Service) GetAllMdsClusters(ctx context.Context, query *MdsClustersQuery, stop utils.StopFunc[model.MdsCluster]) ([]model.MdsCluster, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
 	}
-	return clusters, nil
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsCluster], error) {
+		return s.GetMdsClusters(ctx, query)
+	}, stop)
 }
 
 // GetMdsCluster - Returns the cluster by ID
-func (s *Service) GetMdsCluster(id string) (*model.MdsCluster, error) {
+func (s *Service) GetMdsCluster(ctx context.Context, id string) (*model.MdsCluster, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Clusters, id)
 	var response model.MdsCluster
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -81,14 +74,18 @@ func (s *Service) GetMdsCluster(id string) (*model.MdsCluster, error) {
 }
 
 // CreateMdsCluster - Submits a request to create cluster
-func (s *Service) CreateMdsCluster(requestBody *MdsClusterCreateRequest) (*model.TaskResponse, error) {
+func (s *Service) CreateMdsCluster(ctx context.Context, requestBody *MdsClusterCreateRequest) (*model.TaskResponse, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
+	if requestBody.ClusterMetadata != nil && requestBody.ClusterMetadata.ServiceType() != requestBody.ServiceType {
+		return nil, fmt.Errorf("cluster metadata of service type %s cannot be used for a %s cluster",
+			requestBody.ClusterMetadata.ServiceType(), requestBody.ServiceType)
+	}
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Clusters)
 	var response model.TaskResponse
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -97,7 +94,7 @@ func (s *Service) CreateMdsCluster(requestBody *MdsClusterCreateRequest) (*model
 }
 
 // UpdateMdsCluster - Submits a request to update cluster
-func (s *Service) UpdateMdsCluster(id string, requestBody *MdsClusterUpdateRequest) (*model.MdsCluster, error) {
+func (s *Service) UpdateMdsCluster(ctx context.Context, id string, requestBody *MdsClusterUpdateRequest) (*model.MdsCluster, error) {
 	if id == "" {
 		return nil, fmt.Errorf("cluster ID cannot be empty")
 	}
@@ -107,7 +104,7 @@ func (s *Service) UpdateMdsCluster(id string, requestBody *MdsClusterUpdateReque
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Clusters, id)
 	var response model.MdsCluster
 
-	_, err := s.Api.Patch(&urlPath, requestBody.Tags, &response)
+	_, err := s.Api.Patch(ctx, &urlPath, requestBody.Tags, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -116,7 +113,7 @@ func (s *Service) UpdateMdsCluster(id string, requestBody *MdsClusterUpdateReque
 }
 
 // UpdateMdsClusterNetworkPolicies - Submits a request to update cluster network policies
-func (s *Service) UpdateMdsClusterNetworkPolicies(id string, requestBody *MdsClusterNetworkPoliciesUpdateRequest) ([]byte, error) {
+func (s *Service) UpdateMdsClusterNetworkPolicies(ctx context.Context, id string, requestBody *MdsClusterNetworkPoliciesUpdateRequest) ([]byte, error) {
 	if id == "" {
 		return nil, fmt.Errorf("cluster ID cannot be empty")
 	}
@@ -125,7 +122,7 @@ func (s *Service) UpdateMdsClusterNetworkPolicies(id string, requestBody *MdsClu
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, id, NetworkPolicy)
 
-	bodyBytes, err := s.Api.Patch(&urlPath, requestBody, nil)
+	bodyBytes, err := s.Api.Patch(ctx, &urlPath, requestBody, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -133,12 +130,50 @@ func (s *Service) UpdateMdsClusterNetworkPolicies(id string, requestBody *MdsClu
 	return bodyBytes, nil
 }
 
+// ResizeMdsCluster - Submits a request to change the instance size of cluster
+func (s *Service) ResizeMdsCluster(ctx context.Context, id string, requestBody *MdsClusterResizeRequest) (*model.TaskResponse, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	if requestBody == nil {
+		return nil, fmt.Errorf("requestBody cannot be nil")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, id, Resize)
+	var response model.TaskResponse
+
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// UpdateMdsClusterMaintenance - Submits a request to set the maintenance window and pausing of updates of cluster
+func (s *Service) UpdateMdsClusterMaintenance(ctx context.Context, id string, requestBody *MdsClusterMaintenanceUpdateRequest) (*model.MdsCluster, error) {
+	if id == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	if requestBody == nil {
+		return nil, fmt.Errorf("requestBody cannot be nil")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, id, Maintenance)
+	var response model.MdsCluster
+
+	_, err := s.Api.Patch(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
 // DeleteMdsCluster - Submits a request to delete cluster
-func (s *Service) DeleteMdsCluster(id string) (*model.TaskResponse, error) {
+func (s *Service) DeleteMdsCluster(ctx context.Context, id string) (*model.TaskResponse, error) {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Clusters, id)
 	var response model.TaskResponse
 
-	_, err := s.Api.Delete(&urlPath, nil, &response)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -147,16 +182,32 @@ func (s *Service) DeleteMdsCluster(id string) (*model.TaskResponse, error) {
 }
 
 // GetServiceInstanceTypes - Returns list of clusters
-func (s *Service) GetServiceInstanceTypes(serviceTypeQuery *MdsInstanceTypesQuery) (model.MdsInstanceTypeList, error) {
+func (s *Service) GetServiceInstanceTypes(ctx context.Context, serviceTypeQuery *MdsInstanceTypesQuery) (model.MdsInstanceTypeList, error) {
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, Services, InstanceTypes)
 	var response model.MdsInstanceTypeList
 
-	serviceTypeQuery.ServiceType = serviceTypeQuery.ServiceType
 	if serviceTypeQuery.Size == 0 {
 		serviceTypeQuery.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, serviceTypeQuery, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, serviceTypeQuery, &response)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetServiceVersions - Returns the versions of the service type clusters can be created with
+func (s *Service) GetServiceVersions(ctx context.Context, query *MdsServiceVersionsQuery) (model.MdsServiceVersionList, error) {
+	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, Services, Versions)
+	var response model.MdsServiceVersionList
+
+	if query == nil || strings.TrimSpace(query.ServiceType) == "" {
+		return response, fmt.Errorf("service type cannot be empty")
+	}
+
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -165,17 +216,163 @@ func (s *Service) GetServiceInstanceTypes(serviceTypeQuery *MdsInstanceTypesQuer
 }
 
 // GetMdsClusterMetaData - Returns the cluster metadata by ID
-func (s *Service) GetMdsClusterMetaData(id string) (*model.MdsClusterMetaData, error) {
+func (s *Service) GetMdsClusterMetaData(ctx context.Context, id string) (*model.MdsClusterMetaData, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, id, MetaData)
 	var response model.MdsClusterMetaData
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
 
 	return &response, err
 }
+
+// GetMdsClusterBackups - Returns page of backups of the cluster
+func (s *Service) GetMdsClusterBackups(ctx context.Context, clusterId string, query *MdsClusterBackupsQuery) (model.Paged[model.MdsClusterBackup], error) {
+	var response model.Paged[model.MdsClusterBackup]
+	if strings.TrimSpace(clusterId) == "" {
+		return response, fmt.Errorf("cluster ID cannot be empty")
+	}
+	if query == nil {
+		return response, fmt.Errorf("query cannot be nil")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, Backups)
+
+	if query.Size == 0 {
+		query.Size = defaultPage.Size
+	}
+
+	_, err := s.Api.Get(ctx, &urlPath, query, &response)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetAllMdsClusterBackups - Returns the backups of the cluster of all pages, see utils.ListAll
+func (s *Service) GetAllMdsClusterBackups(ctx context.Context, clusterId string, query *MdsClusterBackupsQuery, stop utils.StopFunc[model.MdsClusterBackup]) ([]model.MdsClusterBackup, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsClusterBackup], error) {
+		return s.GetMdsClusterBackups(ctx, clusterId, query)
+	}, stop)
+}
+
+// GetMdsClusterBackup - Returns the backup of the cluster by ID
+func (s *Service) GetMdsClusterBackup(ctx context.Context, clusterId string, id string) (*model.MdsClusterBackup, error) {
+	if strings.TrimSpace(clusterId) == "" || strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("cluster ID and backup ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, Backups, id)
+	var response model.MdsClusterBackup
+
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// GetMdsBackup - Returns the backup by ID, whichever cluster it belongs to
+func (s *Service) GetMdsBackup(ctx context.Context, id string) (*model.MdsClusterBackup, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Backups, id)
+	var response model.MdsClusterBackup
+
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// CreateMdsClusterBackup - Submits a request to take an on-demand backup of the cluster
+func (s *Service) CreateMdsClusterBackup(ctx context.Context, clusterId string, requestBody *MdsClusterBackupCreateRequest) (*model.TaskResponse, error) {
+	if strings.TrimSpace(clusterId) == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	if requestBody == nil {
+		return nil, fmt.Errorf("requestBody cannot be nil")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, Backups)
+	var response model.TaskResponse
+
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// DeleteMdsClusterBackup - Submits a request to delete the backup of the cluster
+func (s *Service) DeleteMdsClusterBackup(ctx context.Context, clusterId string, id string) (*model.TaskResponse, error) {
+	if strings.TrimSpace(clusterId) == "" || strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("cluster ID and backup ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, Backups, id)
+	var response model.TaskResponse
+
+	_, err := s.Api.Delete(ctx, &urlPath, nil, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// GetMdsClusterBackupPolicy - Returns the backup policy of the cluster
+func (s *Service) GetMdsClusterBackupPolicy(ctx context.Context, clusterId string) (*model.MdsClusterBackupPolicy, error) {
+	if strings.TrimSpace(clusterId) == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, BackupPolicy)
+	var response model.MdsClusterBackupPolicy
+
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// UpdateMdsClusterBackupPolicy - Submits a request to replace the backup policy of the cluster
+func (s *Service) UpdateMdsClusterBackupPolicy(ctx context.Context, clusterId string, requestBody *MdsClusterBackupPolicyUpdateRequest) (*model.MdsClusterBackupPolicy, error) {
+	if strings.TrimSpace(clusterId) == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	if requestBody == nil {
+		return nil, fmt.Errorf("requestBody cannot be nil")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, BackupPolicy)
+	var response model.MdsClusterBackupPolicy
+
+	_, err := s.Api.Put(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// DeleteMdsClusterBackupPolicy - Submits a request to reset the backup policy of the cluster to the default of its service
+func (s *Service) DeleteMdsClusterBackupPolicy(ctx context.Context, clusterId string) error {
+	if strings.TrimSpace(clusterId) == "" {
+		return fmt.Errorf("cluster ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Clusters, clusterId, BackupPolicy)
+
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
+	return err
+}
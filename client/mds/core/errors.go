@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// FieldError - Validation error of a single field of the request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorCode - Known value of ApiError.ErrorCode, registered by the service returning it
+type ErrorCode struct {
+	Code        string
+	Service     string
+	Description string
+}
+
+var (
+	errorCodes     = map[string]ErrorCode{}
+	errorCodesLock sync.RWMutex
+)
+
+// RegisterErrorCodes - Records the error codes returned by the given service, keyed by code with their description
+func RegisterErrorCodes(service string, codes map[string]string) {
+	errorCodesLock.Lock()
+	defer errorCodesLock.Unlock()
+	for code, description := range codes {
+		errorCodes[code] = ErrorCode{Code: code, Service: service, Description: description}
+	}
+}
+
+// LookupErrorCode - Returns the registered error code, false when unknown
+func LookupErrorCode(code string) (ErrorCode, bool) {
+	errorCodesLock.RLock()
+	defer errorCodesLock.RUnlock()
+	errorCode, ok := errorCodes[code]
+	return errorCode, ok
+}
+
+// ErrorCodeOf - Returns the error code sent by MDS, empty if the error did not come with one
+func ErrorCodeOf(err error) string {
+	var apiError ApiError
+	if errors.As(err, &apiError) {
+		return apiError.ErrorCode
+	}
+	return ""
+}
+
+// HasErrorCode - Tells whether MDS failed the call with the given error code
+func HasErrorCode(err error, code string) bool {
+	return code != "" && ErrorCodeOf(err) == code
+}
+
+// FieldErrorsOf - Returns the validation errors of the request fields sent by MDS
+func FieldErrorsOf(err error) []FieldError {
+	var apiError ApiError
+	if errors.As(err, &apiError) {
+		return apiError.FieldErrors
+	}
+	return nil
+}
+
+// IsNotFound - Tells whether the resource does not exist (any longer)
+func IsNotFound(err error) bool {
+	return StatusCodeOf(err) == http.StatusNotFound
+}
+
+// IsConflict - Tells whether the call conflicts with the current state of the resource, e.g. a duplicate name
+func IsConflict(err error) bool {
+	return StatusCodeOf(err) == http.StatusConflict
+}
+
+// IsUnauthorized - Tells whether MDS rejected the credentials or the token
+func IsUnauthorized(err error) bool {
+	return StatusCodeOf(err) == http.StatusUnauthorized
+}
+
+// IsRateLimited - Tells whether MDS throttled the call
+func IsRateLimited(err error) bool {
+	return StatusCodeOf(err) == http.StatusTooManyRequests
+}
+
+// IsRetryable - Tells whether the call failed transiently and may succeed if sent again later
+func IsRetryable(err error) bool {
+	switch StatusCodeOf(err) {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case 0:
+		return err != nil && isTransientNetworkError(err)
+	}
+	return false
+}
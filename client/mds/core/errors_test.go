@@ -0,0 +1,111 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+func apiError(statusCode int) error {
+	return ApiError{HttpError: HttpError{error: fmt.Errorf("status %d", statusCode), StatusCode: statusCode}}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	tests := map[string]struct {
+		err             error
+		wantNotFound    bool
+		wantConflict    bool
+		wantUnauthorize bool
+		wantRateLimited bool
+		wantRetryable   bool
+	}{
+		"not found":          {err: apiError(http.StatusNotFound), wantNotFound: true},
+		"wrapped not found":  {err: fmt.Errorf("reading cluster: %w", apiError(http.StatusNotFound)), wantNotFound: true},
+		"http not found":     {err: HttpError{error: errors.New("not found"), StatusCode: http.StatusNotFound}, wantNotFound: true},
+		"conflict":           {err: apiError(http.StatusConflict), wantConflict: true},
+		"unauthorized":       {err: apiError(http.StatusUnauthorized), wantUnauthorize: true},
+		"rate limited":       {err: apiError(http.StatusTooManyRequests), wantRateLimited: true, wantRetryable: true},
+		"bad gateway":        {err: apiError(http.StatusBadGateway), wantRetryable: true},
+		"unavailable":        {err: apiError(http.StatusServiceUnavailable), wantRetryable: true},
+		"gateway timeout":    {err: apiError(http.StatusGatewayTimeout), wantRetryable: true},
+		"internal error":     {err: apiError(http.StatusInternalServerError)},
+		"bad request":        {err: apiError(http.StatusBadRequest)},
+		"connection reset":   {err: fmt.Errorf("sending request: %w", syscall.ECONNRESET), wantRetryable: true},
+		"unexpected eof":     {err: io.ErrUnexpectedEOF, wantRetryable: true},
+		"dial":               {err: &net.OpError{Op: "dial", Err: errors.New("no route to host")}, wantRetryable: true},
+		"not from a request": {err: errors.New("invalid configuration")},
+		"no error":           {err: nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsNotFound(test.err); got != test.wantNotFound {
+				t.Errorf("expected IsNotFound %t, got %t", test.wantNotFound, got)
+			}
+			if got := IsConflict(test.err); got != test.wantConflict {
+				t.Errorf("expected IsConflict %t, got %t", test.wantConflict, got)
+			}
+			if got := IsUnauthorized(test.err); got != test.wantUnauthorize {
+				t.Errorf("expected IsUnauthorized %t, got %t", test.wantUnauthorize, got)
+			}
+			if got := IsRateLimited(test.err); got != test.wantRateLimited {
+				t.Errorf("expected IsRateLimited %t, got %t", test.wantRateLimited, got)
+			}
+			if got := IsRetryable(test.err); got != test.wantRetryable {
+				t.Errorf("expected IsRetryable %t, got %t", test.wantRetryable, got)
+			}
+		})
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	RegisterErrorCodes("test-service", map[string]string{"TEST_DUPLICATE": "duplicate name"})
+	err := fmt.Errorf("creating: %w", ApiError{
+		HttpError: HttpError{error: errors.New("status 409"), StatusCode: http.StatusConflict},
+		ErrorCode: "TEST_DUPLICATE",
+	})
+
+	if !HasErrorCode(err, "TEST_DUPLICATE") || HasErrorCode(err, "OTHER") || HasErrorCode(apiError(http.StatusConflict), "") {
+		t.Errorf("expected only the sent error code to match")
+	}
+	want := ErrorCode{Code: "TEST_DUPLICATE", Service: "test-service", Description: "duplicate name"}
+	if errorCode, ok := LookupErrorCode(ErrorCodeOf(err)); !ok || errorCode != want {
+		t.Errorf("expected %+v, got %+v", want, errorCode)
+	}
+	if errorCode, ok := LookupErrorCode("TEST_UNKNOWN"); ok {
+		t.Errorf("expected an unknown error code, got %+v", errorCode)
+	}
+	if code := ErrorCodeOf(errors.New("invalid configuration")); code != "" {
+		t.Errorf("expected no error code, got %s", code)
+	}
+}
+
+func TestFieldErrorsOf(t *testing.T) {
+	fieldErrors := []FieldError{{Field: "name", Message: "must not be blank"}, {Field: "tags[0]", Message: "too long"}}
+	tests := map[string]struct {
+		err  error
+		want []FieldError
+	}{
+		"field errors": {
+			err:  ApiError{HttpError: HttpError{error: errors.New("status 400"), StatusCode: http.StatusBadRequest}, FieldErrors: fieldErrors},
+			want: fieldErrors,
+		},
+		"wrapped": {
+			err:  fmt.Errorf("creating: %w", ApiError{HttpError: HttpError{error: errors.New("status 400")}, FieldErrors: fieldErrors}),
+			want: fieldErrors,
+		},
+		"none sent":          {err: apiError(http.StatusBadRequest)},
+		"not from a request": {err: errors.New("invalid configuration")},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := FieldErrorsOf(test.err); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ const (
 	headerAuth        = "csp-auth-token"
 	headerContentType = "content-type"
 	headerTokenType   = "token-type"
+	headerUserAgent   = "user-agent"
 	contentTypeJSON   = "application/json"
 )
 
@@ -26,8 +28,9 @@ type HttpError struct {
 
 type ApiError struct {
 	HttpError
-	ErrorCode    string `json:"errorCode"`
-	ErrorMessage string `json:"errorMsg"`
+	ErrorCode    string       `json:"errorCode"`
+	ErrorMessage string       `json:"errorMsg"`
+	FieldErrors  []FieldError `json:"fieldErrors,omitempty"`
 }
 
 func (h HttpError) Error() string {
@@ -38,40 +41,117 @@ func (h HttpError) Unwrap() error {
 	return h.error
 }
 
+// StatusCodeOf - Returns the HTTP status code carried by the error, 0 if it did not come from an MDS response
+func StatusCodeOf(err error) int {
+	var apiError ApiError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode
+	}
+	var httpError HttpError
+	if errors.As(err, &httpError) {
+		return httpError.StatusCode
+	}
+	return 0
+}
+
 func (r *Root) doRequest(req *http.Request) ([]byte, error) {
-	r.addHeaders(req)
+	if r.OperationTimeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), r.OperationTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	if isAuthRequest(req.Context()) {
+		return r.doRequestWithRetries(req, nil)
+	}
+	if r.Authenticate == nil {
+		token, _ := r.token()
+		return r.doRequestWithRetries(req, token)
+	}
+	return r.doAuthenticatedRequest(req)
+}
+
+func (r *Root) doRequestWithRetries(req *http.Request, token *string) ([]byte, error) {
+	r.addHeaders(req, token)
+
+	policy := r.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	for attempt := 0; ; attempt++ {
+		res, body, err := r.send(req)
+		r.debugAttempt(req, attempt, res, err)
+		if err == nil && (res.StatusCode == http.StatusOK || res.StatusCode == http.StatusAccepted) {
+			return body, nil
+		}
+
+		if attempt >= policy.MaxRetries || !policy.shouldRetry(req, res, err) {
+			if err != nil {
+				return nil, err
+			}
+			return nil, newApiError(res.StatusCode, body)
+		}
+		if err = sleep(req.Context(), policy.backoff(attempt, res)); err != nil {
+			return nil, err
+		}
+		if err = rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
 
+func (r *Root) send(req *http.Request) (*http.Response, []byte, error) {
+	if r.RequestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), r.RequestTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
 	res, err := r.HttpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer closeBody(res.Body)
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return res, body, nil
+}
 
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
-		errorWithMsg := fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
-		var apiError ApiError
-		if err = json.Unmarshal(body, &apiError); err != nil {
-			return nil, errors.Join(errorWithMsg)
-		}
-		apiError.error = errorWithMsg
-		apiError.StatusCode = res.StatusCode
-		return nil, apiError
+func (r *Root) debugAttempt(req *http.Request, attempt int, res *http.Response, err error) {
+	fields := map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"attempt": attempt + 1,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = res.StatusCode
 	}
+	r.debug(req.Context(), "MDS API call", fields)
+}
 
-	return body, nil
+func newApiError(statusCode int, body []byte) error {
+	errorWithMsg := fmt.Errorf("status: %d, body: %s", statusCode, body)
+	var apiError ApiError
+	if err := json.Unmarshal(body, &apiError); err != nil {
+		return HttpError{error: errorWithMsg, StatusCode: statusCode}
+	}
+	apiError.error = errorWithMsg
+	apiError.StatusCode = statusCode
+	return apiError
 }
 
-func (r *Root) addHeaders(req *http.Request) {
+func (r *Root) addHeaders(req *http.Request, token *string) {
 	for header, value := range headers {
-		req.Header.Add(header, value)
+		req.Header.Set(header, value)
+	}
+	if r.UserAgent != "" {
+		req.Header.Set(headerUserAgent, r.UserAgent)
 	}
-	if r.Token != nil {
-		req.Header.Set(headerAuth, " "+*r.Token)
+	if token != nil {
+		req.Header.Set(headerAuth, " "+*token)
 		//	TODO: add token-type
 	}
 }
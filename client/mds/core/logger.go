@@ -0,0 +1,21 @@
+package core
+
+import "context"
+
+// Logger - Receives debug traces of the calls made to MDS, e.g. to forward them to tflog
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields map[string]any)
+}
+
+// LoggerFunc - Adapts a plain function to Logger
+type LoggerFunc func(ctx context.Context, msg string, fields map[string]any)
+
+func (f LoggerFunc) Debug(ctx context.Context, msg string, fields map[string]any) {
+	f(ctx, msg, fields)
+}
+
+func (r *Root) debug(ctx context.Context, msg string, fields map[string]any) {
+	if r.Logger != nil {
+		r.Logger.Debug(ctx, msg, fields)
+	}
+}
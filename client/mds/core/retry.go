@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// RetryPolicy controls how requests failing with transient errors are retried.
+// Idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried on 429, 5xx and broken connections.
+// Other requests (POST, PATCH) are only retried when the server surely did not process them:
+// on 429/503 responses, or when the connection could not be established.
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts made after the first one, 0 disables retries.
+	MaxRetries int
+	// WaitMin is the backoff before the first retry, it doubles on each following attempt.
+	WaitMin time.Duration
+	// WaitMax caps the backoff between two attempts, including the wait the server asks for via Retry-After.
+	WaitMax time.Duration
+}
+
+// DefaultRetryPolicy - Returns the policy used when none is configured
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		WaitMin:    DefaultRetryWaitMin,
+		WaitMax:    DefaultRetryWaitMax,
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	idempotent := isIdempotent(req.Method)
+	if err != nil {
+		if idempotent {
+			return isTransientNetworkError(err)
+		}
+		return isDialError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns the wait before the next attempt, honouring Retry-After when the server sent one.
+// Retry-After is bounded by WaitMax too, so a server asking for hours does not hang the run.
+func (p *RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if wait, ok := retryAfter(res); ok {
+		if wait > p.WaitMax {
+			return p.WaitMax
+		}
+		return wait
+	}
+
+	wait := float64(p.WaitMin) * math.Pow(2, float64(attempt))
+	if wait <= 0 || wait > float64(p.WaitMax) {
+		wait = float64(p.WaitMax)
+	}
+	// jitter between half and full backoff, so parallel resources do not retry in lockstep
+	half := wait / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return isDialError(err)
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
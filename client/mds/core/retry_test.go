@@ -0,0 +1,248 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxRetries: 2,
+	WaitMin:    time.Millisecond,
+	WaitMax:    2 * time.Millisecond,
+}
+
+// countingServer answers every request with the status, and records the attempts and the bodies received.
+type countingServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	attempts int
+	bodies   []string
+}
+
+func newCountingServer(t *testing.T, statuses ...int) *countingServer {
+	t.Helper()
+	server := &countingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		server.bodies = append(server.bodies, string(body))
+		status := statuses[len(statuses)-1]
+		if server.attempts < len(statuses) {
+			status = statuses[server.attempts]
+		}
+		server.attempts++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *countingServer) root() *Root {
+	return &Root{HttpClient: s.Client(), RetryPolicy: testRetryPolicy}
+}
+
+func call(ctx context.Context, root *Root, method string, url string) error {
+	var err error
+	switch method {
+	case http.MethodGet:
+		_, err = root.Get(ctx, &url, nil, nil)
+	case http.MethodPost:
+		_, err = root.Post(ctx, &url, map[string]string{"name": "test"}, nil)
+	case http.MethodPut:
+		_, err = root.Put(ctx, &url, map[string]string{"name": "test"}, nil)
+	case http.MethodPatch:
+		_, err = root.Patch(ctx, &url, map[string]string{"name": "test"}, nil)
+	case http.MethodDelete:
+		_, err = root.Delete(ctx, &url, nil, nil)
+	}
+	return err
+}
+
+func TestRetryByMethodAndStatus(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		status       int
+		wantAttempts int
+	}{
+		"GET succeeds":             {method: http.MethodGet, status: http.StatusOK, wantAttempts: 1},
+		"GET not found":            {method: http.MethodGet, status: http.StatusNotFound, wantAttempts: 1},
+		"GET too many requests":    {method: http.MethodGet, status: http.StatusTooManyRequests, wantAttempts: 3},
+		"GET internal error":       {method: http.MethodGet, status: http.StatusInternalServerError, wantAttempts: 3},
+		"GET unavailable":          {method: http.MethodGet, status: http.StatusServiceUnavailable, wantAttempts: 3},
+		"PUT bad gateway":          {method: http.MethodPut, status: http.StatusBadGateway, wantAttempts: 3},
+		"DELETE gateway timeout":   {method: http.MethodDelete, status: http.StatusGatewayTimeout, wantAttempts: 3},
+		"POST too many requests":   {method: http.MethodPost, status: http.StatusTooManyRequests, wantAttempts: 3},
+		"POST unavailable":         {method: http.MethodPost, status: http.StatusServiceUnavailable, wantAttempts: 3},
+		"POST internal error":      {method: http.MethodPost, status: http.StatusInternalServerError, wantAttempts: 1},
+		"POST bad gateway":         {method: http.MethodPost, status: http.StatusBadGateway, wantAttempts: 1},
+		"PATCH unavailable":        {method: http.MethodPatch, status: http.StatusServiceUnavailable, wantAttempts: 3},
+		"PATCH gateway timeout":    {method: http.MethodPatch, status: http.StatusGatewayTimeout, wantAttempts: 1},
+		"PATCH bad request":        {method: http.MethodPatch, status: http.StatusBadRequest, wantAttempts: 1},
+		"DELETE too many requests": {method: http.MethodDelete, status: http.StatusTooManyRequests, wantAttempts: 3},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newCountingServer(t, test.status)
+
+			err := call(context.Background(), server.root(), test.method, server.URL)
+			if (err != nil) != (test.status != http.StatusOK) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if server.attempts != test.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", test.wantAttempts, server.attempts)
+			}
+			if StatusCodeOf(err) != 0 && StatusCodeOf(err) != test.status {
+				t.Errorf("expected status %d, got %d", test.status, StatusCodeOf(err))
+			}
+		})
+	}
+}
+
+func TestRetryRecovers(t *testing.T) {
+	server := newCountingServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+	if err := call(context.Background(), server.root(), http.MethodGet, server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", server.attempts)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	server := newCountingServer(t, http.StatusServiceUnavailable)
+	root := &Root{HttpClient: server.Client(), RetryPolicy: &RetryPolicy{}}
+
+	if err := call(context.Background(), root, http.MethodGet, server.URL); err == nil {
+		t.Fatal("expected an error")
+	}
+	if server.attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", server.attempts)
+	}
+}
+
+func TestRetryRewindsBody(t *testing.T) {
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+		t.Run(method, func(t *testing.T) {
+			server := newCountingServer(t, http.StatusServiceUnavailable, http.StatusOK)
+
+			if err := call(context.Background(), server.root(), method, server.URL); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(server.bodies) != 2 {
+				t.Fatalf("expected 2 attempts, got %d", len(server.bodies))
+			}
+			for i, body := range server.bodies {
+				if body != `{"name":"test"}` {
+					t.Errorf("attempt %d sent body %q", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnCancelledContext(t *testing.T) {
+	server := newCountingServer(t, http.StatusServiceUnavailable)
+	root := &Root{HttpClient: server.Client(), RetryPolicy: &RetryPolicy{MaxRetries: 5, WaitMin: time.Hour, WaitMax: time.Hour}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := call(ctx, root, http.MethodGet, server.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to end the retries, got: %v", err)
+	}
+	if server.attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", server.attempts)
+	}
+}
+
+func TestShouldRetryNetworkErrors(t *testing.T) {
+	dialError := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	resetError := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	tests := map[string]struct {
+		method string
+		err    error
+		want   bool
+	}{
+		"GET dial error":        {method: http.MethodGet, err: dialError, want: true},
+		"GET connection reset":  {method: http.MethodGet, err: resetError, want: true},
+		"GET unexpected EOF":    {method: http.MethodGet, err: io.ErrUnexpectedEOF, want: true},
+		"GET other error":       {method: http.MethodGet, err: errors.New("malformed response"), want: false},
+		"POST dial error":       {method: http.MethodPost, err: dialError, want: true},
+		"POST connection reset": {method: http.MethodPost, err: resetError, want: false},
+		"PATCH dial error":      {method: http.MethodPatch, err: dialError, want: true},
+		"PATCH unexpected EOF":  {method: http.MethodPatch, err: io.ErrUnexpectedEOF, want: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, "http://mds.example.com", nil)
+			if got := testRetryPolicy.shouldRetry(req, nil, test.err); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://mds.example.com", nil)
+	if testRetryPolicy.shouldRetry(req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil) {
+		t.Error("expected no retry once the context is done")
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	policy := &RetryPolicy{WaitMin: 100 * time.Millisecond, WaitMax: time.Second}
+	for attempt := 0; attempt < 40; attempt++ {
+		full := policy.WaitMin << attempt
+		if attempt >= 4 {
+			full = policy.WaitMax
+		}
+		for i := 0; i < 20; i++ {
+			wait := policy.backoff(attempt, nil)
+			if wait < full/2 || wait > full {
+				t.Fatalf("attempt %d: expected a wait between %s and %s, got %s", attempt, full/2, full, wait)
+			}
+		}
+	}
+}
+
+func TestBackoffHonoursRetryAfter(t *testing.T) {
+	policy := &RetryPolicy{WaitMin: time.Millisecond, WaitMax: 2 * time.Millisecond}
+	response := func(retryAfter string) *http.Response {
+		res := &http.Response{Header: http.Header{}}
+		res.Header.Set("Retry-After", retryAfter)
+		return res
+	}
+
+	if wait := policy.backoff(0, response("3")); wait != policy.WaitMax {
+		t.Errorf("expected a wait of 3s to be capped to %s, got %s", policy.WaitMax, wait)
+	}
+	at := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait := policy.backoff(0, response(at)); wait != policy.WaitMax {
+		t.Errorf("expected a wait until %s to be capped to %s, got %s", at, policy.WaitMax, wait)
+	}
+	patient := &RetryPolicy{WaitMin: time.Millisecond, WaitMax: time.Minute}
+	if wait := patient.backoff(0, response("3")); wait != 3*time.Second {
+		t.Errorf("expected a wait of 3s, got %s", wait)
+	}
+	if wait := patient.backoff(0, response(at)); wait <= 8*time.Second || wait > 10*time.Second {
+		t.Errorf("expected a wait until %s, got %s", at, wait)
+	}
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+	if wait := policy.backoff(0, response(past)); wait != 0 {
+		t.Errorf("expected no wait for a date in the past, got %s", wait)
+	}
+	if wait := policy.backoff(0, response("soon")); wait > policy.WaitMin {
+		t.Errorf("expected an invalid Retry-After to be ignored, got %s", wait)
+	}
+}
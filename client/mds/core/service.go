@@ -1,12 +1,15 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/utils"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Service struct {
@@ -15,11 +18,26 @@ type Service struct {
 }
 
 type Root struct {
-	HostUrl    *string
-	OrgId      string
-	AuthToUse  *model.ClientAuth
-	HttpClient *http.Client
-	Token      *string
+	HostUrl     *string
+	OrgId       string
+	AuthToUse   *model.ClientAuth
+	HttpClient  *http.Client
+	Token       *string
+	TokenExpiry time.Time
+	RetryPolicy *RetryPolicy
+	// RequestTimeout bounds each attempt of a call, OperationTimeout the whole call including retries.
+	// Zero means no limit other than the one of the request context.
+	RequestTimeout   time.Duration
+	OperationTimeout time.Duration
+	// UserAgent is sent with every request when set, Logger receives debug traces of the calls.
+	UserAgent string
+	Logger    Logger
+	// Authenticate obtains a new access token and stores it via SetToken, it's called
+	// whenever the token is about to expire or MDS rejects it. Tokens are not refreshed when nil.
+	Authenticate func(ctx context.Context) error
+
+	tokenLock   sync.RWMutex
+	refreshLock sync.Mutex
 }
 
 func NewService(hostUrl *string, endPoint string, root *Root) *Service {
@@ -29,8 +47,8 @@ func NewService(hostUrl *string, endPoint string, root *Root) *Service {
 	}
 }
 
-func (r *Root) Get(url *string, queryModel interface{}, dest interface{}) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, *url, nil)
+func (r *Root) Get(ctx context.Context, url *string, queryModel interface{}, dest interface{}) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +75,13 @@ func (r *Root) Get(url *string, queryModel interface{}, dest interface{}) ([]byt
 	return body, nil
 }
 
-func (r *Root) Post(url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
+func (r *Root) Post(ctx context.Context, url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
 	rb, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, *url, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *url, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -86,13 +104,13 @@ func (r *Root) Post(url *string, reqBody interface{}, dest interface{}) ([]byte,
 	return body, nil
 }
 
-func (r *Root) Delete(url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
+func (r *Root) Delete(ctx context.Context, url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
 	rb, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodDelete, *url, strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, *url, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -111,14 +129,15 @@ func (r *Root) Delete(url *string, reqBody interface{}, dest interface{}) ([]byt
 	return body, nil
 }
 
-func (r *Root) Patch(url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
+func (r *Root) Patch(ctx context.Context, url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
 	rb, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("BODY: %s", reqBody)
-	req, err := http.NewRequest(http.MethodPatch, *url, strings.NewReader(string(rb)))
+	// bodies carry passwords and credentials, only their size is traced
+	r.debug(ctx, "MDS API request body", map[string]any{"method": http.MethodPatch, "url": *url, "size": len(rb)})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, *url, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -137,14 +156,15 @@ func (r *Root) Patch(url *string, reqBody interface{}, dest interface{}) ([]byte
 	return body, nil
 }
 
-func (r *Root) Put(url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
+func (r *Root) Put(ctx context.Context, url *string, reqBody interface{}, dest interface{}) ([]byte, error) {
 	rb, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("BODY: %s", rb)
-	req, err := http.NewRequest(http.MethodPut, *url, strings.NewReader(string(rb)))
+	// bodies carry passwords and credentials, only their size is traced
+	r.debug(ctx, "MDS API request body", map[string]any{"method": http.MethodPut, "url": *url, "size": len(rb)})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, *url, strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRequestBodiesNotLogged(t *testing.T) {
+	server := newCountingServer(t, http.StatusOK)
+	var logged []string
+	root := server.root()
+	root.Logger = LoggerFunc(func(ctx context.Context, msg string, fields map[string]any) {
+		logged = append(logged, fmt.Sprintf("%s %v", msg, fields))
+	})
+
+	body := map[string]string{"username": "admin", "password": "s3cr3t"}
+	if _, err := root.Patch(context.Background(), &server.URL, body, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := root.Put(context.Background(), &server.URL, body, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(logged) == 0 {
+		t.Fatal("expected the calls to be traced")
+	}
+	for _, line := range logged {
+		if strings.Contains(line, "s3cr3t") {
+			t.Errorf("request body leaked to the debug log: %s", line)
+		}
+	}
+}
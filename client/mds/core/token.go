@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// tokenRefreshLeeway is how long before its expiry an access token gets renewed,
+// so that a request does not reach MDS with a token expiring on the way.
+const tokenRefreshLeeway = 1 * time.Minute
+
+type authRequestKey struct{}
+
+// WithoutAuthentication - Marks the context of a request which obtains the access token itself,
+// such request is sent without the token and never triggers a refresh.
+func WithoutAuthentication(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authRequestKey{}, true)
+}
+
+func isAuthRequest(ctx context.Context) bool {
+	skip, _ := ctx.Value(authRequestKey{}).(bool)
+	return skip
+}
+
+// SetToken - Stores the access token to send with subsequent requests; a zero expiry means it never expires
+func (r *Root) SetToken(token string, expiry time.Time) {
+	r.tokenLock.Lock()
+	defer r.tokenLock.Unlock()
+	r.Token = &token
+	r.TokenExpiry = expiry
+}
+
+func (r *Root) token() (*string, time.Time) {
+	r.tokenLock.RLock()
+	defer r.tokenLock.RUnlock()
+	return r.Token, r.TokenExpiry
+}
+
+// validToken returns the current token, renewing it first when it is missing or about to expire.
+func (r *Root) validToken(ctx context.Context) (*string, error) {
+	token, expiry := r.token()
+	if token != nil && (expiry.IsZero() || time.Now().Add(tokenRefreshLeeway).Before(expiry)) {
+		return token, nil
+	}
+	return r.renewToken(ctx, token)
+}
+
+// renewToken authenticates again, unless another request already replaced the stale token meanwhile.
+// Refreshes are serialised, so resources running in parallel trigger a single authentication.
+func (r *Root) renewToken(ctx context.Context, stale *string) (*string, error) {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+
+	if current, _ := r.token(); current != nil && current != stale {
+		return current, nil
+	}
+	if err := r.Authenticate(WithoutAuthentication(ctx)); err != nil {
+		return nil, err
+	}
+	current, _ := r.token()
+	return current, nil
+}
+
+// doAuthenticatedRequest sends the request with a valid token, and replays it once with a
+// renewed token when MDS rejects it as unauthorized (token revoked or expired earlier than announced).
+func (r *Root) doAuthenticatedRequest(req *http.Request) ([]byte, error) {
+	token, err := r.validToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := r.doRequestWithRetries(req, token)
+	if StatusCodeOf(err) != http.StatusUnauthorized {
+		return body, err
+	}
+
+	if token, err = r.renewToken(req.Context(), token); err != nil {
+		return nil, err
+	}
+	if err = rewindBody(req); err != nil {
+		return nil, err
+	}
+	return r.doRequestWithRetries(req, token)
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// authServer stands in for MDS: its token endpoint issues a new token on each call,
+// the API endpoint accepts the latest issued token only.
+type authServer struct {
+	*httptest.Server
+	lock      sync.Mutex
+	issued    int
+	apiCalls  int
+	validUpTo int
+	// rejectAll makes the API endpoint answer 401 to every token
+	rejectAll bool
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	t.Helper()
+	server := &authServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		switch r.URL.Path {
+		case "/auth":
+			if r.Header.Get(headerAuth) != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			server.issued++
+			server.validUpTo = server.issued
+			_, _ = fmt.Fprintf(w, `{"token":"token-%d"}`, server.issued)
+		case "/api":
+			server.apiCalls++
+			token := strings.TrimSpace(r.Header.Get(headerAuth))
+			if server.rejectAll || token != fmt.Sprintf("token-%d", server.validUpTo) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"errorCode":"UNAUTHORIZED","errorMsg":"invalid token"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *authServer) counts() (issued int, apiCalls int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.issued, s.apiCalls
+}
+
+// revoke makes the API endpoint reject the tokens issued so far, as when MDS expires them earlier than announced.
+func (s *authServer) revoke() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.validUpTo = -1
+}
+
+// root returns a client authenticating against the token endpoint, with tokens valid for tokenLifetime.
+func (s *authServer) root(tokenLifetime time.Duration) *Root {
+	root := &Root{HttpClient: s.Client(), RetryPolicy: &RetryPolicy{}}
+	root.Authenticate = func(ctx context.Context) error {
+		authUrl := s.URL + "/auth"
+		var response struct {
+			Token string `json:"token"`
+		}
+		if _, err := root.Post(ctx, &authUrl, nil, &response); err != nil {
+			return err
+		}
+		root.SetToken(response.Token, time.Now().Add(tokenLifetime))
+		return nil
+	}
+	return root
+}
+
+func (s *authServer) get(ctx context.Context, root *Root) error {
+	apiUrl := s.URL + "/api"
+	_, err := root.Get(ctx, &apiUrl, nil, nil)
+	return err
+}
+
+func TestTokenObtainedOnFirstRequest(t *testing.T) {
+	server := newAuthServer(t)
+	root := server.root(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if err := server.get(context.Background(), root); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if issued, apiCalls := server.counts(); issued != 1 || apiCalls != 3 {
+		t.Errorf("expected 1 token for 3 calls, got %d tokens for %d calls", issued, apiCalls)
+	}
+}
+
+func TestTokenRefreshedWithinLeeway(t *testing.T) {
+	tests := map[string]struct {
+		expiresIn   time.Duration
+		wantRefresh bool
+	}{
+		"expired":             {expiresIn: -time.Minute, wantRefresh: true},
+		"expiring in leeway":  {expiresIn: tokenRefreshLeeway / 2, wantRefresh: true},
+		"valid beyond leeway": {expiresIn: 2 * tokenRefreshLeeway, wantRefresh: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newAuthServer(t)
+			root := server.root(time.Hour)
+			if err := server.get(context.Background(), root); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			token, _ := root.token()
+			root.SetToken(*token, time.Now().Add(test.expiresIn))
+
+			if err := server.get(context.Background(), root); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			issued, _ := server.counts()
+			if refreshed := issued == 2; refreshed != test.wantRefresh {
+				t.Errorf("expected refresh %t, got %d tokens issued", test.wantRefresh, issued)
+			}
+		})
+	}
+}
+
+func TestTokenRefreshedOnceForConcurrentRequests(t *testing.T) {
+	server := newAuthServer(t)
+	root := server.root(time.Hour)
+	root.SetToken("stale", time.Now().Add(-time.Minute))
+
+	const requests = 20
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- server.get(context.Background(), root)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if issued, apiCalls := server.counts(); issued != 1 || apiCalls != requests {
+		t.Errorf("expected 1 refresh for %d calls, got %d refreshes for %d calls", requests, issued, apiCalls)
+	}
+}
+
+func TestTokenReplayedOnceOnUnauthorized(t *testing.T) {
+	server := newAuthServer(t)
+	root := server.root(time.Hour)
+	if err := server.get(context.Background(), root); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.revoke()
+
+	if err := server.get(context.Background(), root); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// first call, the rejected one and its replay
+	if issued, apiCalls := server.counts(); issued != 2 || apiCalls != 3 {
+		t.Errorf("expected 2 tokens and 3 calls, got %d tokens and %d calls", issued, apiCalls)
+	}
+}
+
+func TestTokenNotReplayedTwice(t *testing.T) {
+	server := newAuthServer(t)
+	root := server.root(time.Hour)
+	server.rejectAll = true
+
+	err := server.get(context.Background(), root)
+	if StatusCodeOf(err) != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthorized error, got: %v", err)
+	}
+	if issued, apiCalls := server.counts(); issued != 2 || apiCalls != 2 {
+		t.Errorf("expected a single replay, got %d tokens and %d calls", issued, apiCalls)
+	}
+}
+
+func TestTokenNotRefreshedWithoutAuthenticate(t *testing.T) {
+	server := newAuthServer(t)
+	root := &Root{HttpClient: server.Client(), RetryPolicy: &RetryPolicy{}}
+	root.SetToken("stale", time.Now().Add(-time.Minute))
+
+	err := server.get(context.Background(), root)
+	if StatusCodeOf(err) != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthorized error, got: %v", err)
+	}
+	if issued, apiCalls := server.counts(); issued != 0 || apiCalls != 1 {
+		t.Errorf("expected no refresh and no replay, got %d tokens and %d calls", issued, apiCalls)
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMinPollInterval = 5 * time.Second
+	DefaultMaxPollInterval = 30 * time.Second
+)
+
+// StateRefreshFunc - Returns the object being waited on and its current state.
+// A nil object (or a not found error) means the object does not exist.
+type StateRefreshFunc[T any] func(ctx context.Context) (*T, string, error)
+
+// StateChangeConf - Waits for an object to reach one of the target states, polling it with a growing interval.
+type StateChangeConf[T any] struct {
+	// Pending are the states allowed while waiting, any state but the targets is when empty.
+	Pending []string
+	// Target are the states ending the wait. When empty, the wait ends once the object does not exist.
+	Target  []string
+	Refresh StateRefreshFunc[T]
+	// Timeout bounds the wait in addition to the deadline of the context, 0 means no bound.
+	Timeout time.Duration
+	// Delay is the wait before the first refresh.
+	Delay time.Duration
+	// MinPollInterval is the wait after the first refresh, it doubles on each refresh up to MaxPollInterval.
+	MinPollInterval time.Duration
+	MaxPollInterval time.Duration
+	// NotFoundChecks is the number of consecutive refreshes the object may not exist for,
+	// e.g. while MDS has not made a created object visible yet.
+	NotFoundChecks int
+	// OnRefresh is called with the object after each refresh it exists in, e.g. to report the progress of a long wait.
+	OnRefresh func(result *T, state string)
+}
+
+// UnexpectedStateError - Returned when the object reaches a state which is neither pending nor a target
+type UnexpectedStateError struct {
+	State    string
+	Expected []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state '%s', wanted target '%s'", e.State, strings.Join(e.Expected, ", "))
+}
+
+// WaitForState - Refreshes the object until it reaches a target state and returns it. Waiting stops with an error
+// when the refresh fails, the object is in an unexpected state, it's missing for too long, or time is up.
+func (c *StateChangeConf[T]) WaitForState(ctx context.Context) (*T, error) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	interval := c.MinPollInterval
+	if interval <= 0 {
+		interval = DefaultMinPollInterval
+	}
+	maxInterval := c.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	if err := sleep(ctx, c.Delay); err != nil {
+		return nil, fmt.Errorf("timeout while waiting before first refresh: %w", err)
+	}
+	lastState, notFound := "", 0
+	for {
+		result, state, err := c.Refresh(ctx)
+		if err != nil && !IsNotFound(err) {
+			return result, err
+		}
+
+		if err != nil || result == nil {
+			if len(c.Target) == 0 {
+				return nil, nil
+			}
+			notFound++
+			if notFound > c.NotFoundChecks {
+				return nil, fmt.Errorf("couldn't find resource (%d retries)", c.NotFoundChecks)
+			}
+		} else {
+			notFound = 0
+			lastState = state
+			if c.OnRefresh != nil {
+				c.OnRefresh(result, state)
+			}
+			if contains(c.Target, state) {
+				return result, nil
+			}
+			if len(c.Pending) > 0 && !contains(c.Pending, state) {
+				return result, &UnexpectedStateError{State: state, Expected: c.Target}
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			if lastState == "" {
+				return result, fmt.Errorf("timeout while waiting for state to become '%s': %w", strings.Join(c.Target, ", "), err)
+			}
+			return result, fmt.Errorf("timeout while waiting for state to become '%s' (last state: '%s'): %w",
+				strings.Join(c.Target, ", "), lastState, err)
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type waited struct {
+	state string
+}
+
+func refreshSequence(states ...string) StateRefreshFunc[waited] {
+	calls := 0
+	return func(ctx context.Context) (*waited, string, error) {
+		state := states[calls]
+		if calls < len(states)-1 {
+			calls++
+		}
+		if state == "" {
+			return nil, "", HttpError{error: errors.New("not found"), StatusCode: http.StatusNotFound}
+		}
+		return &waited{state: state}, state, nil
+	}
+}
+
+func TestWaitForState(t *testing.T) {
+	tests := map[string]struct {
+		conf      StateChangeConf[waited]
+		wantState string
+		wantErr   bool
+	}{
+		"reaches target": {
+			conf:      StateChangeConf[waited]{Pending: []string{"CREATING"}, Target: []string{"READY"}, Refresh: refreshSequence("CREATING", "CREATING", "READY")},
+			wantState: "READY",
+		},
+		"any pending state when none given": {
+			conf:      StateChangeConf[waited]{Target: []string{"READY"}, Refresh: refreshSequence("QUEUED", "CREATING", "READY")},
+			wantState: "READY",
+		},
+		"unexpected state": {
+			conf:    StateChangeConf[waited]{Pending: []string{"CREATING"}, Target: []string{"READY"}, Refresh: refreshSequence("CREATING", "FAILED")},
+			wantErr: true,
+		},
+		"tolerates not found": {
+			conf:      StateChangeConf[waited]{Target: []string{"READY"}, NotFoundChecks: 2, Refresh: refreshSequence("", "", "READY")},
+			wantState: "READY",
+		},
+		"not found for too long": {
+			conf:    StateChangeConf[waited]{Target: []string{"READY"}, NotFoundChecks: 1, Refresh: refreshSequence("", "", "READY")},
+			wantErr: true,
+		},
+		"waits until gone": {
+			conf: StateChangeConf[waited]{Pending: []string{"DELETING"}, Refresh: refreshSequence("DELETING", "")},
+		},
+		"times out": {
+			conf:    StateChangeConf[waited]{Target: []string{"READY"}, Timeout: 20 * time.Millisecond, Refresh: refreshSequence("CREATING")},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.conf.MinPollInterval = time.Millisecond
+			test.conf.MaxPollInterval = 4 * time.Millisecond
+			result, err := test.conf.WaitForState(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.wantState != "" && (result == nil || result.state != test.wantState) {
+				t.Errorf("expected state %s, got %v", test.wantState, result)
+			}
+		})
+	}
+}
+
+func TestWaitForStateOnRefresh(t *testing.T) {
+	var states []string
+	conf := StateChangeConf[waited]{
+		Target:          []string{"READY"},
+		NotFoundChecks:  1,
+		Refresh:         refreshSequence("", "CREATING", "READY"),
+		MinPollInterval: time.Millisecond,
+		OnRefresh: func(result *waited, state string) {
+			states = append(states, state)
+		},
+	}
+	if _, err := conf.WaitForState(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(states) != 2 || states[0] != "CREATING" || states[1] != "READY" {
+		t.Errorf("expected to be called for CREATING and READY, got %v", states)
+	}
+}
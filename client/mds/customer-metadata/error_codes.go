@@ -1,5 +1,13 @@
 package customer_metadata
 
+import "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+
 const (
 	DuplicateServiceAccount = "DUPLICATE_SERVICE_ACCOUNT"
 )
+
+func init() {
+	core.RegisterErrorCodes(EndPoint, map[string]string{
+		DuplicateServiceAccount: "a service account with the same name already exists",
+	})
+}
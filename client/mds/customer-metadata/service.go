@@ -1,10 +1,12 @@
 package customer_metadata
 
 import (
+	"context"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/account_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/utils"
 	"strings"
 )
 
@@ -30,7 +32,7 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 }
 
 // GetPolicies - Returns list of Policies
-func (s *Service) GetPolicies(query *MdsPoliciesQuery) (model.Paged[model.MdsPolicy], error) {
+func (s *Service) GetPolicies(ctx context.Context, query *MdsPoliciesQuery) (model.Paged[model.MdsPolicy], error) {
 	reqUrl := fmt.Sprintf("%s/%s", s.Endpoint, Policies)
 	var response model.Paged[model.MdsPolicy]
 
@@ -38,15 +40,25 @@ func (s *Service) GetPolicies(query *MdsPoliciesQuery) (model.Paged[model.MdsPol
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
 	return response, nil
 }
 
+// GetAllPolicies - Returns the policies of all pages, see utils.ListAll
+func (s *Service) GetAllPolicies(ctx context.Context, query *MdsPoliciesQuery, stop utils.StopFunc[model.MdsPolicy]) ([]model.MdsPolicy, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsPolicy], error) {
+		return s.GetPolicies(ctx, query)
+	}, stop)
+}
+
 // GetMdsUsers - Return list of Users
-func (s *Service) GetMdsUsers(query *MdsUsersQuery) (model.Paged[model.MdsUser], error) {
+func (s *Service) GetMdsUsers(ctx context.Context, query *MdsUsersQuery) (model.Paged[model.MdsUser], error) {
 	var response model.Paged[model.MdsUser]
 	if query == nil {
 		return response, fmt.Errorf("query cannot be nil")
@@ -59,22 +71,32 @@ func (s *Service) GetMdsUsers(query *MdsUsersQuery) (model.Paged[model.MdsUser],
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
 	return response, nil
 }
 
+// GetAllMdsUsers - Returns the users of all pages, see utils.ListAll
+func (s *Service) GetAllMdsUsers(ctx context.Context, query *MdsUsersQuery, stop utils.StopFunc[model.MdsUser]) ([]model.MdsUser, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsUser], error) {
+		return s.GetMdsUsers(ctx, query)
+	}, stop)
+}
+
 // CreateMdsUser - Submits a request to create user
-func (s *Service) CreateMdsUser(requestBody *MdsCreateUserRequest) error {
+func (s *Service) CreateMdsUser(ctx context.Context, requestBody *MdsCreateUserRequest) error {
 	if requestBody == nil {
 		return fmt.Errorf("requestBody cannot be nil")
 	}
 	requestBody.AccountType = account_type.USER_ACCOUNT
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Users)
 
-	_, err := s.Api.Post(&urlPath, requestBody, nil)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, nil)
 	if err != nil {
 		return err
 	}
@@ -83,7 +105,7 @@ func (s *Service) CreateMdsUser(requestBody *MdsCreateUserRequest) error {
 }
 
 // UpdateMdsUser - Submits a request to update user
-func (s *Service) UpdateMdsUser(id string, requestBody *MdsUserUpdateRequest) error {
+func (s *Service) UpdateMdsUser(ctx context.Context, id string, requestBody *MdsUserUpdateRequest) error {
 	if id == "" {
 		return fmt.Errorf("user ID cannot be empty")
 	}
@@ -92,19 +114,19 @@ func (s *Service) UpdateMdsUser(id string, requestBody *MdsUserUpdateRequest) er
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
 
-	_, err := s.Api.Patch(&urlPath, requestBody, nil)
+	_, err := s.Api.Patch(ctx, &urlPath, requestBody, nil)
 	return err
 }
 
 // GetMdsUser - Returns the user by ID
-func (s *Service) GetMdsUser(id string) (*model.MdsUser, error) {
+func (s *Service) GetMdsUser(ctx context.Context, id string) (*model.MdsUser, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
 	var response model.MdsUser
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -113,10 +135,10 @@ func (s *Service) GetMdsUser(id string) (*model.MdsUser, error) {
 }
 
 // DeleteMdsUser - Submits a request to delete user
-func (s *Service) DeleteMdsUser(id string) error {
+func (s *Service) DeleteMdsUser(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -125,7 +147,7 @@ func (s *Service) DeleteMdsUser(id string) error {
 }
 
 // GetMdsServiceAccounts - Return list of Service Accounts
-func (s *Service) GetMdsServiceAccounts(query *MdsServiceAccountsQuery) (model.Paged[model.MdsServiceAccount], error) {
+func (s *Service) GetMdsServiceAccounts(ctx context.Context, query *MdsServiceAccountsQuery) (model.Paged[model.MdsServiceAccount], error) {
 
 	var response model.Paged[model.MdsServiceAccount]
 	if query == nil {
@@ -139,7 +161,7 @@ func (s *Service) GetMdsServiceAccounts(query *MdsServiceAccountsQuery) (model.P
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -147,8 +169,18 @@ func (s *Service) GetMdsServiceAccounts(query *MdsServiceAccountsQuery) (model.P
 	return response, nil
 }
 
+// GetAllMdsServiceAccounts - Returns the service accounts of all pages, see utils.ListAll
+func (s *Service) GetAllMdsServiceAccounts(ctx context.Context, query *MdsServiceAccountsQuery, stop utils.StopFunc[model.MdsServiceAccount]) ([]model.MdsServiceAccount, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsServiceAccount], error) {
+		return s.GetMdsServiceAccounts(ctx, query)
+	}, stop)
+}
+
 // CreateMdsServiceAccount - Submits a request to create service account
-func (s *Service) CreateMdsServiceAccount(requestBody *MdsCreateSvcAccountRequest) (*model.MdsServiceAccountCreate, error) {
+func (s *Service) CreateMdsServiceAccount(ctx context.Context, requestBody *MdsCreateSvcAccountRequest) (*model.MdsServiceAccountCreate, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
@@ -157,7 +189,7 @@ func (s *Service) CreateMdsServiceAccount(requestBody *MdsCreateSvcAccountReques
 
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Users)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -166,12 +198,12 @@ func (s *Service) CreateMdsServiceAccount(requestBody *MdsCreateSvcAccountReques
 }
 
 // GetMDSServiceAccountOauthApp - Fetch oauthDetails for the service account
-func (s *Service) GetMDSServiceAccountOauthApp(id string) (*model.MDSServieAccountOauthApp, error) {
+func (s *Service) GetMDSServiceAccountOauthApp(ctx context.Context, id string) (*model.MDSServieAccountOauthApp, error) {
 
 	var response model.MDSServieAccountOauthApp
 
 	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, Users, id, OAuthApps)
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -180,12 +212,12 @@ func (s *Service) GetMDSServiceAccountOauthApp(id string) (*model.MDSServieAccou
 }
 
 // UpdateMDSServiceAccountOauthApp - To Update the Oauth app details
-func (s *Service) UpdateMDSServiceAccountOauthApp(id string, requestBody *MDSOauthAppUpdateRequest, appId string) (*model.MDSServieAccountOauthApp, error) {
+func (s *Service) UpdateMDSServiceAccountOauthApp(ctx context.Context, id string, requestBody *MDSOauthAppUpdateRequest, appId string) (*model.MDSServieAccountOauthApp, error) {
 
 	var response model.MDSServieAccountOauthApp
 
 	urlPath := fmt.Sprintf("%s/%s/%s/%s/%s", s.Endpoint, Users, id, OAuthApps, appId)
-	_, err := s.Api.Patch(&urlPath, requestBody, &response)
+	_, err := s.Api.Patch(ctx, &urlPath, requestBody, &response)
 
 	if err != nil {
 		return &response, err
@@ -195,7 +227,7 @@ func (s *Service) UpdateMDSServiceAccountOauthApp(id string, requestBody *MDSOau
 }
 
 // UpdateMdsServiceAccount - Submits a request to update service account
-func (s *Service) UpdateMdsServiceAccount(id string, requestBody *MdsSvcAccountUpdateRequest) error {
+func (s *Service) UpdateMdsServiceAccount(ctx context.Context, id string, requestBody *MdsSvcAccountUpdateRequest) error {
 	if id == "" {
 		return fmt.Errorf("service account ID cannot be empty")
 	}
@@ -203,19 +235,19 @@ func (s *Service) UpdateMdsServiceAccount(id string, requestBody *MdsSvcAccountU
 		return fmt.Errorf("requestBody cannot be nil")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
-	_, err := s.Api.Patch(&urlPath, requestBody, nil)
+	_, err := s.Api.Patch(ctx, &urlPath, requestBody, nil)
 	return err
 }
 
 // GetMdsServiceAccount - Returns the service account by ID
-func (s *Service) GetMdsServiceAccount(id string) (*model.MdsServiceAccount, error) {
+func (s *Service) GetMdsServiceAccount(ctx context.Context, id string) (*model.MdsServiceAccount, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
 	var response model.MdsServiceAccount
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -224,10 +256,10 @@ func (s *Service) GetMdsServiceAccount(id string) (*model.MdsServiceAccount, err
 }
 
 // DeleteMdsServiceAccount - Submits a request to delete service account
-func (s *Service) DeleteMdsServiceAccount(id string) error {
+func (s *Service) DeleteMdsServiceAccount(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Users, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -236,14 +268,14 @@ func (s *Service) DeleteMdsServiceAccount(id string) error {
 }
 
 // CreatePolicy - Submits a request to create policy
-func (s *Service) CreatePolicy(requestBody *MdsCreateUpdatePolicyRequest) (*model.MdsPolicy, error) {
+func (s *Service) CreatePolicy(ctx context.Context, requestBody *MdsCreateUpdatePolicyRequest) (*model.MdsPolicy, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
 	var response model.MdsPolicy
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Policies)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -252,7 +284,7 @@ func (s *Service) CreatePolicy(requestBody *MdsCreateUpdatePolicyRequest) (*mode
 }
 
 // UpdateMdsPolicy - Submits a request to update policy
-func (s *Service) UpdateMdsPolicy(id string, requestBody *MdsCreateUpdatePolicyRequest) error {
+func (s *Service) UpdateMdsPolicy(ctx context.Context, id string, requestBody *MdsCreateUpdatePolicyRequest) error {
 	if id == "" {
 		return fmt.Errorf("policy ID cannot be empty")
 	}
@@ -261,19 +293,19 @@ func (s *Service) UpdateMdsPolicy(id string, requestBody *MdsCreateUpdatePolicyR
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Policies, id)
 
-	_, err := s.Api.Put(&urlPath, requestBody, nil)
+	_, err := s.Api.Put(ctx, &urlPath, requestBody, nil)
 	return err
 }
 
 // GetMDSPolicy - Submits a request to fetch policy
-func (s *Service) GetMDSPolicy(id string) (*model.MdsPolicy, error) {
+func (s *Service) GetMDSPolicy(ctx context.Context, id string) (*model.MdsPolicy, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Policies, id)
 	var response model.MdsPolicy
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -282,10 +314,10 @@ func (s *Service) GetMDSPolicy(id string) (*model.MdsPolicy, error) {
 }
 
 // DeleteMdsPolicy - Submits a request to delete policy
-func (s *Service) DeleteMdsPolicy(id string) error {
+func (s *Service) DeleteMdsPolicy(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Policies, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,11 @@
 package infra_connector
 
 import (
+	"context"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/utils"
 	"strings"
 )
 
@@ -28,12 +30,12 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 	}
 }
 
-func (s *Service) GetRegionsWithDataPlanes(regionsQuery *DataPlaneRegionsQuery) (map[string][]string, error) {
+func (s *Service) GetRegionsWithDataPlanes(ctx context.Context, regionsQuery *DataPlaneRegionsQuery) (map[string][]string, error) {
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, K8sCluster, Resource)
 
 	var response map[string][]string
 
-	_, err := s.Api.Get(&reqUrl, regionsQuery, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, regionsQuery, &response)
 	if err != nil {
 		return response, err
 	}
@@ -41,7 +43,7 @@ func (s *Service) GetRegionsWithDataPlanes(regionsQuery *DataPlaneRegionsQuery)
 	return response, nil
 }
 
-func (s *Service) GetCloudAccounts(query *MdsCloudAccountsQuery) (model.Paged[model.MdsCloudAccount], error) {
+func (s *Service) GetCloudAccounts(ctx context.Context, query *MdsCloudAccountsQuery) (model.Paged[model.MdsCloudAccount], error) {
 	var response model.Paged[model.MdsCloudAccount]
 	if query == nil {
 		return response, fmt.Errorf("query cannot be nil")
@@ -53,22 +55,32 @@ func (s *Service) GetCloudAccounts(query *MdsCloudAccountsQuery) (model.Paged[mo
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
 	return response, nil
 }
 
+// GetAllCloudAccounts - Returns the cloud accounts of all pages, see utils.ListAll
+func (s *Service) GetAllCloudAccounts(ctx context.Context, query *MdsCloudAccountsQuery, stop utils.StopFunc[model.MdsCloudAccount]) ([]model.MdsCloudAccount, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsCloudAccount], error) {
+		return s.GetCloudAccounts(ctx, query)
+	}, stop)
+}
+
 // GetCloudAccount - Submits a request to fetch cloud account
-func (s *Service) GetCloudAccount(id string) (*model.MdsCloudAccount, error) {
+func (s *Service) GetCloudAccount(ctx context.Context, id string) (*model.MdsCloudAccount, error) {
 	if strings.TrimSpace(id) == "" {
 		return nil, fmt.Errorf("ID cannot be empty")
 	}
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, CloudAccount, id)
 	var response model.MdsCloudAccount
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -76,7 +88,7 @@ func (s *Service) GetCloudAccount(id string) (*model.MdsCloudAccount, error) {
 	return &response, err
 }
 
-func (s *Service) GetCertificates(query *MDSCertificateQuery) (model.Paged[model.MdsCertificate], error) {
+func (s *Service) GetCertificates(ctx context.Context, query *MDSCertificateQuery) (model.Paged[model.MdsCertificate], error) {
 	var response model.Paged[model.MdsCertificate]
 	if query == nil {
 		return response, fmt.Errorf("query cannot be nil")
@@ -88,14 +100,24 @@ func (s *Service) GetCertificates(query *MDSCertificateQuery) (model.Paged[model
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
 	return response, nil
 }
 
-func (s *Service) GetTshirtSizes(query *MdsTshirtSizesQuery) (model.Paged[model.MdsTshirtSize], error) {
+// GetAllCertificates - Returns the certificates of all pages, see utils.ListAll
+func (s *Service) GetAllCertificates(ctx context.Context, query *MDSCertificateQuery, stop utils.StopFunc[model.MdsCertificate]) ([]model.MdsCertificate, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsCertificate], error) {
+		return s.GetCertificates(ctx, query)
+	}, stop)
+}
+
+func (s *Service) GetTshirtSizes(ctx context.Context, query *MdsTshirtSizesQuery) (model.Paged[model.MdsTshirtSize], error) {
 	var response model.Paged[model.MdsTshirtSize]
 	if query == nil {
 		return response, fmt.Errorf("query cannot be nil")
@@ -107,18 +129,28 @@ func (s *Service) GetTshirtSizes(query *MdsTshirtSizesQuery) (model.Paged[model.
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
 	return response, nil
 }
 
-func (s *Service) GetProviderTypes() ([]string, error) {
+// GetAllTshirtSizes - Returns the t-shirt sizes of all pages, see utils.ListAll
+func (s *Service) GetAllTshirtSizes(ctx context.Context, query *MdsTshirtSizesQuery, stop utils.StopFunc[model.MdsTshirtSize]) ([]model.MdsTshirtSize, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.MdsTshirtSize], error) {
+		return s.GetTshirtSizes(ctx, query)
+	}, stop)
+}
+
+func (s *Service) GetProviderTypes(ctx context.Context) ([]string, error) {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, CloudAccount, Types)
 	var response []string
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -126,12 +158,12 @@ func (s *Service) GetProviderTypes() ([]string, error) {
 	return response, err
 }
 
-func (s *Service) GetDataPlaneRegions() ([]model.MdsDataPlaneRegion, error) {
+func (s *Service) GetDataPlaneRegions(ctx context.Context) ([]model.MdsDataPlaneRegion, error) {
 	var response []model.MdsDataPlaneRegion
 
 	reqUrl := fmt.Sprintf("%s/%s", s.Endpoint, CloudProviders)
 
-	_, err := s.Api.Get(&reqUrl, nil, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -139,14 +171,14 @@ func (s *Service) GetDataPlaneRegions() ([]model.MdsDataPlaneRegion, error) {
 }
 
 // CreateDataPlane - Submits a request to create dataplane
-func (s *Service) CreateDataPlane(requestBody *DataPlaneCreateRequest) (*model.TaskResponse, error) {
+func (s *Service) CreateDataPlane(ctx context.Context, requestBody *DataPlaneCreateRequest) (*model.TaskResponse, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
 	var response model.TaskResponse
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, K8sCluster)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -154,7 +186,7 @@ func (s *Service) CreateDataPlane(requestBody *DataPlaneCreateRequest) (*model.T
 	return &response, err
 }
 
-func (s *Service) GetDataPlanes(query *DataPlaneQuery) (model.Paged[model.DataPlane], error) {
+func (s *Service) GetDataPlanes(ctx context.Context, query *DataPlaneQuery) (model.Paged[model.DataPlane], error) {
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, K8sCluster)
 	var response model.Paged[model.DataPlane]
 
@@ -162,7 +194,7 @@ func (s *Service) GetDataPlanes(query *DataPlaneQuery) (model.Paged[model.DataPl
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&urlPath, query, &response)
+	_, err := s.Api.Get(ctx, &urlPath, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -170,11 +202,21 @@ func (s *Service) GetDataPlanes(query *DataPlaneQuery) (model.Paged[model.DataPl
 	return response, nil
 }
 
-func (s *Service) GetDataPlaneById(id string) (model.DataPlane, error) {
+// GetAllDataPlanes - Returns the data planes of all pages, see utils.ListAll
+func (s *Service) GetAllDataPlanes(ctx context.Context, query *DataPlaneQuery, stop utils.StopFunc[model.DataPlane]) ([]model.DataPlane, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+	return utils.ListAll(ctx, &query.PageQuery, func(ctx context.Context) (model.Paged[model.DataPlane], error) {
+		return s.GetDataPlanes(ctx, query)
+	}, stop)
+}
+
+func (s *Service) GetDataPlaneById(ctx context.Context, id string) (model.DataPlane, error) {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, K8sCluster, id)
 	var response model.DataPlane
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -183,10 +225,10 @@ func (s *Service) GetDataPlaneById(id string) (model.DataPlane, error) {
 }
 
 // DeleteDataPlane - Submits a request to delete dataplane
-func (s *Service) DeleteDataPlane(id string) error {
+func (s *Service) DeleteDataPlane(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, K8sCluster, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -194,14 +236,14 @@ func (s *Service) DeleteDataPlane(id string) error {
 	return nil
 }
 
-func (s *Service) CreateCloudAccount(requestBody *CloudAccountCreateRequest) (*model.MdsCloudAccount, error) {
+func (s *Service) CreateCloudAccount(ctx context.Context, requestBody *CloudAccountCreateRequest) (*model.MdsCloudAccount, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
 	var response model.MdsCloudAccount
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, CloudAccount)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -210,10 +252,10 @@ func (s *Service) CreateCloudAccount(requestBody *CloudAccountCreateRequest) (*m
 }
 
 // UpdateCloudAccount - To Update the cloud account
-func (s *Service) UpdateCloudAccount(id string, requestBody *CredentialModel) error {
+func (s *Service) UpdateCloudAccount(ctx context.Context, id string, requestBody *CredentialModel) error {
 
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, CloudAccount, id)
-	_, err := s.Api.Put(&urlPath, requestBody, nil)
+	_, err := s.Api.Put(ctx, &urlPath, requestBody, nil)
 
 	if err != nil {
 		return err
@@ -223,10 +265,10 @@ func (s *Service) UpdateCloudAccount(id string, requestBody *CredentialModel) er
 }
 
 // DeleteCloudAccount - Submits a request to delete cloud account
-func (s *Service) DeleteCloudAccount(id string) error {
+func (s *Service) DeleteCloudAccount(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, CloudAccount, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -234,14 +276,14 @@ func (s *Service) DeleteCloudAccount(id string) error {
 	return nil
 }
 
-func (s *Service) CreateCertificate(requestBody *CertificateCreateRequest) (*model.MdsCertificate, error) {
+func (s *Service) CreateCertificate(ctx context.Context, requestBody *CertificateCreateRequest) (*model.MdsCertificate, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
 	var response model.MdsCertificate
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Certificate)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -249,14 +291,14 @@ func (s *Service) CreateCertificate(requestBody *CertificateCreateRequest) (*mod
 	return &response, err
 }
 
-func (s *Service) UpdateCertificate(id string, requestBody *CertificateUpdateRequest) (*model.MdsCertificate, error) {
+func (s *Service) UpdateCertificate(ctx context.Context, id string, requestBody *CertificateUpdateRequest) (*model.MdsCertificate, error) {
 	if requestBody == nil {
 		return nil, fmt.Errorf("requestBody cannot be nil")
 	}
 	var response model.MdsCertificate
 	urlPath := fmt.Sprintf("%s	/%s/%s", s.Endpoint, Certificate, id)
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -264,12 +306,12 @@ func (s *Service) UpdateCertificate(id string, requestBody *CertificateUpdateReq
 	return &response, err
 }
 
-func (s *Service) GetCertificate(id string) (model.MdsCertificate, error) {
+func (s *Service) GetCertificate(ctx context.Context, id string) (model.MdsCertificate, error) {
 	var response model.MdsCertificate
 
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, Certificate, id)
 
-	_, err := s.Api.Get(&reqUrl, nil, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -277,10 +319,10 @@ func (s *Service) GetCertificate(id string) (model.MdsCertificate, error) {
 }
 
 // DeleteCertificate - Submits a request to delete certificate
-func (s *Service) DeleteCertificate(id string) error {
+func (s *Service) DeleteCertificate(ctx context.Context, id string) error {
 	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Certificate, id)
 
-	_, err := s.Api.Delete(&urlPath, nil, nil)
+	_, err := s.Api.Delete(ctx, &urlPath, nil, nil)
 	if err != nil {
 		return err
 	}
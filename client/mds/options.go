@@ -0,0 +1,86 @@
+package mds
+
+import (
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"net/http"
+)
+
+// Option - Customises the client built by NewClientWithOptions
+type Option func(*options)
+
+type options struct {
+	config     Config
+	httpClient *http.Client
+	logger     core.Logger
+	userAgent  string
+	lazyAuth   bool
+}
+
+// WithConfig - Applies the settings of Config, nil fields are left untouched
+func WithConfig(config *Config) Option {
+	return func(o *options) {
+		if config == nil {
+			return
+		}
+		if config.RetryPolicy != nil {
+			o.config.RetryPolicy = config.RetryPolicy
+		}
+		if config.TLS != nil {
+			o.config.TLS = config.TLS
+		}
+		if config.Transport != nil {
+			o.config.Transport = config.Transport
+		}
+	}
+}
+
+// WithHTTPClient - Sends requests through the given client instead of one built from the TLS and
+// transport settings, e.g. to inject a test transport. Timeouts of the transport settings still apply.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy - Overrides core.DefaultRetryPolicy
+func WithRetryPolicy(retryPolicy *core.RetryPolicy) Option {
+	return func(o *options) {
+		o.config.RetryPolicy = retryPolicy
+	}
+}
+
+// WithTLSConfig - Sets the TLS trust of the connection to MDS
+func WithTLSConfig(tlsConfig *TLSConfig) Option {
+	return func(o *options) {
+		o.config.TLS = tlsConfig
+	}
+}
+
+// WithTransportConfig - Sets the proxy, connection pool and timeouts of the client
+func WithTransportConfig(transportConfig *TransportConfig) Option {
+	return func(o *options) {
+		o.config.Transport = transportConfig
+	}
+}
+
+// WithLogger - Sends debug traces of every call to the logger
+func WithLogger(logger core.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithUserAgent - Sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithLazyAuth - Defers authentication to the first call, so the client can be built without reaching MDS.
+// Invalid credentials are then reported by that first call.
+func WithLazyAuth() Option {
+	return func(o *options) {
+		o.lazyAuth = true
+	}
+}
@@ -1,6 +1,7 @@
 package service_metadata
 
 import (
+	"context"
 	"fmt"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
@@ -27,12 +28,12 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 	}
 }
 
-func (s *Service) GetNetworkPorts() ([]model.MDSNetworkPorts, error) {
+func (s *Service) GetNetworkPorts(ctx context.Context) ([]model.MDSNetworkPorts, error) {
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, MdsServices, NetworkPorts)
 
 	var response []model.MDSNetworkPorts
 
-	_, err := s.Api.Get(&reqUrl, nil, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -41,7 +42,7 @@ func (s *Service) GetNetworkPorts() ([]model.MDSNetworkPorts, error) {
 }
 
 // GetMdsRoles - Return list of Roles for the users
-func (s *Service) GetMdsRoles(query *MDSRolesQuery) (model.MdsRoles, error) {
+func (s *Service) GetMdsRoles(ctx context.Context, query *MDSRolesQuery) (model.MdsRoles, error) {
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, MdsServices, Roles)
 	var response model.MdsRoles
 
@@ -49,7 +50,7 @@ func (s *Service) GetMdsRoles(query *MDSRolesQuery) (model.MdsRoles, error) {
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -57,15 +58,14 @@ func (s *Service) GetMdsRoles(query *MDSRolesQuery) (model.MdsRoles, error) {
 }
 
 // GetMdsServiceRoles - Return list of Roles for the service
-func (s *Service) GetMdsServiceRoles(query *MDSRolesQuery) (model.MdsServiceRoles, error) {
+func (s *Service) GetMdsServiceRoles(ctx context.Context, query *MDSRolesQuery) (model.MdsServiceRoles, error) {
 	reqUrl := fmt.Sprintf("%s/%s/%s", s.Endpoint, MdsServices, Roles)
 	var response model.MdsServiceRoles
-	query.Type = query.Type
 	if query.Size == 0 {
 		query.Size = defaultPage.Size
 	}
 
-	_, err := s.Api.Get(&reqUrl, query, &response)
+	_, err := s.Api.Get(ctx, &reqUrl, query, &response)
 	if err != nil {
 		return response, err
 	}
@@ -73,11 +73,11 @@ func (s *Service) GetMdsServiceRoles(query *MDSRolesQuery) (model.MdsServiceRole
 }
 
 // GetPolicyTypes - Returns the policy types
-func (s *Service) GetPolicyTypes() ([]string, error) {
+func (s *Service) GetPolicyTypes(ctx context.Context) ([]string, error) {
 	urlPath := fmt.Sprintf("%s/%s/%s/%s", s.Endpoint, MdsServices, Policies, Types)
 	var response []string
 
-	_, err := s.Api.Get(&urlPath, nil, &response)
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
 	if err != nil {
 		return response, err
 	}
@@ -0,0 +1,5 @@
+package task_service
+
+const (
+	Tasks = "tasks"
+)
@@ -0,0 +1,93 @@
+package task_service
+
+import (
+	"context"
+	"fmt"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/task_status"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"strings"
+	"time"
+)
+
+const (
+	EndPoint = "taskservice"
+
+	DefaultPollInterval = 10 * time.Second
+)
+
+type Service struct {
+	*core.Service
+	// PollInterval is the wait between two checks of WaitForTask
+	PollInterval time.Duration
+}
+
+func NewService(hostUrl *string, root *core.Root) *Service {
+	return &Service{
+		Service:      core.NewService(hostUrl, EndPoint, root),
+		PollInterval: DefaultPollInterval,
+	}
+}
+
+// TaskFailedError - Returned by WaitForTask when the task did not succeed
+type TaskFailedError struct {
+	Task *model.MdsTask
+}
+
+func (e *TaskFailedError) Error() string {
+	reason := e.Task.FailureReason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	return fmt.Sprintf("task %s ended with status %s: %s", e.Task.Id, e.Task.Status, reason)
+}
+
+// GetTask - Returns the task by ID
+func (s *Service) GetTask(ctx context.Context, id string) (*model.MdsTask, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Tasks, id)
+	var response model.MdsTask
+
+	_, err := s.Api.Get(ctx, &urlPath, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// WaitForTask - Polls the task until it ends, returns a *TaskFailedError when it did not succeed.
+// Waiting is bound by the deadline of ctx.
+func (s *Service) WaitForTask(ctx context.Context, id string) (*model.MdsTask, error) {
+	return s.WaitForTaskProgress(ctx, id, nil)
+}
+
+// WaitForTaskProgress - Same as WaitForTask, calling onProgress with the task on each poll when it's not nil
+func (s *Service) WaitForTaskProgress(ctx context.Context, id string, onProgress func(task *model.MdsTask)) (*model.MdsTask, error) {
+	waiter := core.StateChangeConf[model.MdsTask]{
+		Pending: []string{task_status.QUEUED, task_status.IN_PROGRESS},
+		Target:  []string{task_status.SUCCESS},
+		Refresh: func(ctx context.Context) (*model.MdsTask, string, error) {
+			task, err := s.GetTask(ctx, id)
+			if err != nil {
+				return nil, "", err
+			}
+			if task_status.IsTerminal(task.Status) && task.Status != task_status.SUCCESS {
+				return task, task.Status, &TaskFailedError{Task: task}
+			}
+			return task, task.Status, nil
+		},
+		MinPollInterval: s.PollInterval,
+		MaxPollInterval: s.PollInterval,
+		// a task may not be visible right after the operation was submitted
+		NotFoundChecks: 3,
+	}
+	if onProgress != nil {
+		waiter.OnRefresh = func(task *model.MdsTask, _ string) {
+			onProgress(task)
+		}
+	}
+	return waiter.WaitForState(ctx)
+}
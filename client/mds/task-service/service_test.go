@@ -0,0 +1,169 @@
+package task_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/task_status"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// taskServer answers the polls of task "task-1" with the given tasks in turn, a nil task meaning not found.
+// The last task is repeated once all were served.
+type taskServer struct {
+	*httptest.Server
+	lock  sync.Mutex
+	polls int
+}
+
+func newTaskServer(t *testing.T, tasks ...*model.MdsTask) *taskServer {
+	t.Helper()
+	server := &taskServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+		if r.Method != http.MethodGet || r.URL.Path != "/api/taskservice/tasks/task-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		task := tasks[len(tasks)-1]
+		if server.polls < len(tasks) {
+			task = tasks[server.polls]
+		}
+		server.polls++
+		if task == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMsg": "task not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(task)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *taskServer) service() *Service {
+	service := NewService(&s.URL, &core.Root{
+		HttpClient:  s.Client(),
+		RetryPolicy: &core.RetryPolicy{WaitMin: time.Millisecond, WaitMax: time.Millisecond},
+	})
+	service.PollInterval = time.Millisecond
+	return service
+}
+
+func (s *taskServer) polled() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.polls
+}
+
+func task(status string, progress int) *model.MdsTask {
+	return &model.MdsTask{Id: "task-1", Status: status, Progress: progress, ResourceId: "cluster-1"}
+}
+
+func TestWaitForTaskProgress(t *testing.T) {
+	failed := task(task_status.FAILED, 40)
+	failed.FailureReason = "quota exceeded"
+	tests := map[string]struct {
+		tasks        []*model.MdsTask
+		wantStatus   string
+		wantProgress []int
+		wantFailure  string
+		wantErr      bool
+	}{
+		"success": {
+			tasks:        []*model.MdsTask{task(task_status.QUEUED, 0), task(task_status.IN_PROGRESS, 50), task(task_status.SUCCESS, 100)},
+			wantStatus:   task_status.SUCCESS,
+			wantProgress: []int{0, 50, 100},
+		},
+		"failed": {
+			tasks:        []*model.MdsTask{task(task_status.IN_PROGRESS, 20), failed},
+			wantStatus:   task_status.FAILED,
+			wantProgress: []int{20},
+			wantFailure:  "task task-1 ended with status FAILED: quota exceeded",
+			wantErr:      true,
+		},
+		"cancelled": {
+			tasks:        []*model.MdsTask{task(task_status.QUEUED, 0), task(task_status.CANCELLED, 0)},
+			wantStatus:   task_status.CANCELLED,
+			wantProgress: []int{0},
+			wantFailure:  "task task-1 ended with status CANCELLED: no reason given",
+			wantErr:      true,
+		},
+		"not visible yet": {
+			tasks:        []*model.MdsTask{nil, nil, task(task_status.IN_PROGRESS, 10), task(task_status.SUCCESS, 100)},
+			wantStatus:   task_status.SUCCESS,
+			wantProgress: []int{10, 100},
+		},
+		"not found for too long": {
+			tasks:   []*model.MdsTask{nil},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := newTaskServer(t, test.tasks...)
+			var progress []int
+			result, err := server.service().WaitForTaskProgress(context.Background(), "task-1", func(task *model.MdsTask) {
+				progress = append(progress, task.Progress)
+			})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got: %v", test.wantErr, err)
+			}
+
+			var failedErr *TaskFailedError
+			if isFailure := errors.As(err, &failedErr); isFailure != (test.wantFailure != "") {
+				t.Fatalf("expected a failed task %t, got: %v", test.wantFailure != "", err)
+			}
+			if failedErr != nil && (failedErr.Error() != test.wantFailure || failedErr.Task.ResourceId != "cluster-1") {
+				t.Errorf("expected the failed task %q, got %q for %+v", test.wantFailure, failedErr.Error(), failedErr.Task)
+			}
+			if test.wantStatus != "" && (result == nil || result.Status != test.wantStatus) {
+				t.Errorf("expected the task in status %s, got %+v", test.wantStatus, result)
+			}
+			if len(progress) != len(test.wantProgress) {
+				t.Fatalf("expected the progress %v reported, got %v", test.wantProgress, progress)
+			}
+			for i := range progress {
+				if progress[i] != test.wantProgress[i] {
+					t.Errorf("expected the progress %v reported, got %v", test.wantProgress, progress)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForTaskCancelled(t *testing.T) {
+	server := newTaskServer(t, task(task_status.IN_PROGRESS, 10))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := server.service().WaitForTask(ctx, "task-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got: %v", err)
+	}
+	var failedErr *TaskFailedError
+	if errors.As(err, &failedErr) {
+		t.Errorf("expected the task not to be reported failed, got: %v", err)
+	}
+	if server.polled() == 0 {
+		t.Error("expected the task polled until the context ended")
+	}
+}
+
+func TestWaitForTaskWithoutId(t *testing.T) {
+	server := newTaskServer(t, task(task_status.SUCCESS, 100))
+	if _, err := server.service().WaitForTask(context.Background(), " "); err == nil {
+		t.Error("expected an error for an empty task ID")
+	}
+	if polls := server.polled(); polls != 0 {
+		t.Errorf("expected no call to MDS, got %d", polls)
+	}
+}
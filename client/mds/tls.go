@@ -0,0 +1,74 @@
+package mds
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSConfig - Trust settings for the connection to MDS. Certificates and keys are given either
+// as PEM content or as path to a PEM file.
+type TLSConfig struct {
+	// CACert is a bundle of authorities trusted in addition to the system ones.
+	CACert string
+	// ClientCert and ClientKey identify the client when MDS requires mutual TLS.
+	ClientCert string
+	ClientKey  string
+	// Insecure disables verification of the MDS certificate, it must not be used in production.
+	Insecure bool
+}
+
+func prepareTLSConfig(config *TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config == nil {
+		return tlsConfig, nil
+	}
+	tlsConfig.InsecureSkipVerify = config.Insecure
+
+	if strings.TrimSpace(config.CACert) != "" {
+		caPEM, err := readPEM(config.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no valid PEM certificate found in CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	hasCert, hasKey := strings.TrimSpace(config.ClientCert) != "", strings.TrimSpace(config.ClientKey) != ""
+	if hasCert != hasKey {
+		return nil, fmt.Errorf("client certificate and client key must be provided together")
+	}
+	if hasCert {
+		certPEM, err := readPEM(config.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("reading client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPEM returns the value itself when it holds PEM content, otherwise reads the file it points to.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,68 @@
+package mds
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	DefaultRequestTimeout      = 2 * time.Minute
+	DefaultOperationTimeout    = 60 * time.Minute
+)
+
+// TransportConfig - Connection settings of the MDS client, zero fields fall back to defaults.
+type TransportConfig struct {
+	// ProxyURL is the proxy requests go through, HTTPS_PROXY/HTTP_PROXY/NO_PROXY are honoured when empty.
+	ProxyURL string
+	// MaxIdleConns is the number of keep-alive connections kept open to MDS.
+	MaxIdleConns        int
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	// RequestTimeout bounds a single attempt of an API call, a timed out attempt may be retried.
+	RequestTimeout time.Duration
+	// OperationTimeout bounds a whole API call, including retries and token refresh.
+	OperationTimeout time.Duration
+}
+
+func prepareTransport(config *TransportConfig, tlsConfig *tls.Config) (*http.Transport, error) {
+	proxy := http.ProxyFromEnvironment
+	if strings.TrimSpace(config.ProxyURL) != "" {
+		proxyUrl, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", config.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyUrl)
+	}
+
+	maxIdleConns := valueOrDefault(config.MaxIdleConns, DefaultMaxIdleConns)
+	dialer := &net.Dialer{
+		Timeout:   valueOrDefault(config.DialTimeout, DefaultDialTimeout),
+		KeepAlive: 30 * time.Second,
+	}
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   valueOrDefault(config.TLSHandshakeTimeout, DefaultTLSHandshakeTimeout),
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}, nil
+}
+
+func valueOrDefault[T int | time.Duration](value T, defaultValue T) T {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -1,9 +1,12 @@
 package upgrade_service
 
 import (
+	"context"
 	"fmt"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/upgrade_request_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"strings"
 )
 
 var (
@@ -28,11 +31,57 @@ func NewService(hostUrl *string, root *core.Root) *Service {
 }
 
 // UpdateMdsClusterVersion updates the version of the MDS cluster
-func (s *Service) UpdateMdsClusterVersion(id string, requestBody *UpdateMdsClusterVersionRequest) (*model.UpdateMdsClusterVersionResponse, error) {
+func (s *Service) UpdateMdsClusterVersion(ctx context.Context, id string, requestBody *UpdateMdsClusterVersionRequest) (*model.UpdateMdsClusterVersionResponse, error) {
 	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Upgrade)
 	var response model.UpdateMdsClusterVersionResponse
 
-	_, err := s.Api.Post(&urlPath, requestBody, &response)
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// UpdateDataPlaneVersion upgrades the BYOC dataplane
+func (s *Service) UpdateDataPlaneVersion(ctx context.Context, id string, requestBody *UpdateDataPlaneVersionRequest) (*model.UpdateMdsClusterVersionResponse, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("dataplane ID cannot be empty")
+	}
+	urlPath := fmt.Sprintf("%s/%s", s.Endpoint, Upgrade)
+	var response model.UpdateMdsClusterVersionResponse
+
+	requestBody.Id = id
+	requestBody.RequestType = upgrade_request_type.DATA_PLANE
+	_, err := s.Api.Post(ctx, &urlPath, requestBody, &response)
+	if err != nil {
+		return &response, err
+	}
+
+	return &response, nil
+}
+
+// GetMdsClusterUpgradeTargets returns the versions the MDS cluster can be upgraded to
+func (s *Service) GetMdsClusterUpgradeTargets(ctx context.Context, id string) (*model.MdsUpgradeTargets, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("cluster ID cannot be empty")
+	}
+	return s.getUpgradeTargets(ctx, id, upgrade_request_type.SERVICE)
+}
+
+// GetDataPlaneUpgradeTargets returns the versions the BYOC dataplane can be upgraded to
+func (s *Service) GetDataPlaneUpgradeTargets(ctx context.Context, id string) (*model.MdsUpgradeTargets, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("dataplane ID cannot be empty")
+	}
+	return s.getUpgradeTargets(ctx, id, upgrade_request_type.DATA_PLANE)
+}
+
+func (s *Service) getUpgradeTargets(ctx context.Context, id string, requestType string) (*model.MdsUpgradeTargets, error) {
+	urlPath := fmt.Sprintf("%s/%s/%s", s.Endpoint, Upgrade, Targets)
+	var response model.MdsUpgradeTargets
+
+	_, err := s.Api.Get(ctx, &urlPath, &MdsUpgradeTargetsQuery{Id: id, RequestType: requestType}, &response)
 	if err != nil {
 		return &response, err
 	}
@@ -2,4 +2,5 @@ package upgrade_service
 
 const (
 	Upgrade = "upgrade"
+	Targets = "targets"
 )
@@ -0,0 +1,6 @@
+package upgrade_service
+
+type MdsUpgradeTargetsQuery struct {
+	Id          string `schema:"id"`
+	RequestType string `schema:"requestType"`
+}
@@ -0,0 +1,8 @@
+package upgrade_service
+
+// UpdateDataPlaneVersionRequest represents the request structure for upgrading a BYOC dataplane
+type UpdateDataPlaneVersionRequest struct {
+	Id            string `json:"id"`
+	RequestType   string `json:"requestType"`
+	TargetVersion string `json:"targetVersion"`
+}
@@ -7,3 +7,7 @@ type MdsCloudAccount struct {
 	AccountType string `json:"accountType"`
 	OrgId       string `json:"orgId"`
 }
+
+func (MdsCloudAccount) EmbeddedKey() string {
+	return "cloudAccountDTOes"
+}
@@ -12,6 +12,10 @@ type DataPlane struct {
 	TshirtSize           string      `json:"nodePoolType"`
 }
 
+func (DataPlane) EmbeddedKey() string {
+	return "k8sClusterDTOes"
+}
+
 type Certificate struct {
 	DomainName string `json:"domainName"`
 	Name       string `json:"name"`
@@ -7,3 +7,7 @@ type MdsTshirtSize struct {
 	Storage  string `json:"storage"`
 	Type     string `json:"type"`
 }
+
+func (MdsTshirtSize) EmbeddedKey() string {
+	return "tshirtSizeDTOes"
+}
@@ -8,3 +8,7 @@ type MdsCertificate struct {
 	ExpiryTime string `json:"expirationTime"`
 	CreatedBy  string `json:"createdBy,omitempty"`
 }
+
+func (MdsCertificate) EmbeddedKey() string {
+	return "certificateDTOes"
+}
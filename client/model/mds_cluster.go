@@ -13,6 +13,9 @@ type MdsCluster struct {
 	Version              string              `json:"version"`
 	Status               string              `json:"status"`
 	DataPlaneId          string              `json:"dataPlaneId"`
+	StoragePolicyName    string              `json:"storagePolicyName,omitempty"`
+	Dedicated            *bool               `json:"dedicated,omitempty"`
+	Shared               *bool               `json:"shared,omitempty"`
 	Metadata             *MdsClusterMetadata `json:"metadata"`
 	Created              string              `json:"created"`
 	LastUpdated          string              `json:"lastUpdated"`
@@ -23,6 +26,10 @@ type MdsCluster struct {
 	PauseUpdates         bool                `json:"pauseUpdates"`
 }
 
+func (MdsCluster) EmbeddedKey() string {
+	return "mdsClusterDTOes"
+}
+
 type MdsClusterMetadata struct {
 	ClusterName      string   `json:"clusterName,omitempty" tfsdk:"cluster_name"`
 	ManagerUri       string   `json:"managerUri,omitempty" tfsdk:"manager_uri"`
@@ -0,0 +1,19 @@
+package model
+
+// MdsClusterBackup - Backup of the data of a cluster, taken on demand or on schedule
+type MdsClusterBackup struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	ClusterId   string `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+	ServiceType string `json:"serviceType"`
+	Version     string `json:"version"`
+	BackupType  string `json:"backupType"`
+	Size        string `json:"size"`
+	Status      string `json:"status"`
+	Created     string `json:"timeCreated"`
+}
+
+func (MdsClusterBackup) EmbeddedKey() string {
+	return "mdsBackupDTOes"
+}
@@ -0,0 +1,11 @@
+package model
+
+// MdsClusterBackupPolicy - Schedule and retention of the automated backups of a cluster
+type MdsClusterBackupPolicy struct {
+	ClusterId      string `json:"clusterId"`
+	CronSchedule   string `json:"cronSchedule"`
+	DailyWindow    string `json:"dailyWindow"`
+	RetentionCount int64  `json:"retentionCount"`
+	RetentionDays  int64  `json:"retentionDays"`
+	PitrEnabled    bool   `json:"pitrEnabled"`
+}
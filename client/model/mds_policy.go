@@ -9,6 +9,11 @@ type MdsPolicy struct {
 	PermissionsSpec []*MdsPermissionsSpec `json:"permissionsSpec,omitempty"`
 	NetworkSpec     []*MdsNetworkSpec     `json:"networkSpecs,omitempty"`
 }
+
+func (MdsPolicy) EmbeddedKey() string {
+	return "mdsPolicyDTOes"
+}
+
 type MdsPermissionsSpec struct {
 	Resource    string            `json:"resource"`
 	Permissions []*MdsPermissions `json:"permissions"`
@@ -7,6 +7,11 @@ type MdsServiceAccount struct {
 	Tags   []string `json:"tags"`
 }
 
+// service accounts are listed by the users endpoint
+func (MdsServiceAccount) EmbeddedKey() string {
+	return "mdsUserDTOes"
+}
+
 type MdsServiceAccountCreate struct {
 	OAuthCredentials []*MdsServiceAccountAuthCredentials `json:"oauthCredentials,omitempty"`
 }
@@ -0,0 +1,20 @@
+package model
+
+type MdsServiceVersionList struct {
+	Versions []MdsServiceVersion `json:"versions"`
+}
+
+// MdsServiceVersion - Version of a service clusters can be created with
+type MdsServiceVersion struct {
+	ServiceType string `json:"serviceType"`
+	Version     string `json:"version"`
+	Status      string `json:"status"`
+	IsDefault   bool   `json:"isDefault"`
+}
+
+// MdsUpgradeTargets - Versions a cluster or a dataplane can be upgraded to from its current version
+type MdsUpgradeTargets struct {
+	Id             string   `json:"id"`
+	CurrentVersion string   `json:"currentVersion"`
+	TargetVersions []string `json:"targetVersions"`
+}
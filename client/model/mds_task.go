@@ -0,0 +1,14 @@
+package model
+
+// MdsTask - Asynchronous operation run by MDS, e.g. the creation of a cluster
+type MdsTask struct {
+	Id            string `json:"id"`
+	TaskType      string `json:"taskType"`
+	Status        string `json:"status"`
+	Progress      int    `json:"progress"`
+	ResourceId    string `json:"resourceId"`
+	ResourceName  string `json:"resourceName"`
+	FailureReason string `json:"failureReason,omitempty"`
+	Created       string `json:"timeCreated"`
+	LastUpdated   string `json:"timeUpdated"`
+}
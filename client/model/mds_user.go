@@ -9,3 +9,7 @@ type MdsUser struct {
 	ServiceRoles []MdsRoleMini `json:"serviceRoles"`
 	Tags         []string      `json:"tags"`
 }
+
+func (MdsUser) EmbeddedKey() string {
+	return "mdsUserDTOes"
+}
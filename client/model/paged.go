@@ -1,8 +1,11 @@
 package model
 
+import "sort"
+
 type Paged[T any] struct {
-	Embedded map[string][]T `json:"_embedded"`
-	Page     PageInfo       `json:"page"`
+	Embedded map[string][]T  `json:"_embedded"`
+	Links    map[string]Link `json:"_links"`
+	Page     PageInfo        `json:"page"`
 }
 
 type PageInfo struct {
@@ -17,15 +20,55 @@ type PageQuery struct {
 	Size  int `schema:"size"`
 }
 
+// Link - HAL link to a related page
+type Link struct {
+	Href string `json:"href"`
+}
+
+const (
+	LinkSelf = "self"
+	LinkNext = "next"
+	LinkPrev = "prev"
+)
+
+// Embeddable - Implemented by the resources listed in pages, tells the key of their collection in `_embedded`
+type Embeddable interface {
+	EmbeddedKey() string
+}
+
+// Get - Returns the items of the page. The collection is looked up by the key of the item type when it
+// implements Embeddable, otherwise (or when absent) the single collection, or the first one by key is used.
 func (p *Paged[T]) Get() *[]T {
-	empty := make([]T, 0)
-	var items = &empty
-	for _, v := range p.Embedded {
-		items = &v
+	if len(p.Embedded) == 0 {
+		empty := make([]T, 0)
+		return &empty
 	}
-	return items
+
+	var item T
+	if embeddable, ok := any(item).(Embeddable); ok {
+		if items, ok := p.Embedded[embeddable.EmbeddedKey()]; ok {
+			return &items
+		}
+	}
+
+	keys := make([]string, 0, len(p.Embedded))
+	for key := range p.Embedded {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	items := p.Embedded[keys[0]]
+	return &items
 }
 
 func (p *Paged[T]) GetPage() *PageInfo {
 	return &p.Page
 }
+
+// GetLink - Returns the link of the given relation, nil when the response has none
+func (p *Paged[T]) GetLink(rel string) *Link {
+	link, ok := p.Links[rel]
+	if !ok || link.Href == "" {
+		return nil
+	}
+	return &link
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// namedItem is listed without telling its embedded key
+type namedItem struct {
+	Name string `json:"name"`
+}
+
+func TestPagedGet(t *testing.T) {
+	tests := map[string]struct {
+		body         string
+		wantClusters []string
+		wantItems    []string
+	}{
+		"expected key among others": {
+			body:         `{"_embedded": {"aDTOes": [{"name": "a"}], "mdsClusterDTOes": [{"name": "cluster"}], "zDTOes": [{"name": "z"}]}}`,
+			wantClusters: []string{"cluster"},
+			wantItems:    []string{"a"},
+		},
+		"single collection under another key": {
+			body:         `{"_embedded": {"clusters": [{"name": "cluster"}, {"name": "other"}]}}`,
+			wantClusters: []string{"cluster", "other"},
+			wantItems:    []string{"cluster", "other"},
+		},
+		"first key without the expected one": {
+			body:         `{"_embedded": {"zDTOes": [{"name": "z"}], "bDTOes": [{"name": "b"}], "mDTOes": [{"name": "m"}]}}`,
+			wantClusters: []string{"b"},
+			wantItems:    []string{"b"},
+		},
+		"empty collection": {
+			body:         `{"_embedded": {"mdsClusterDTOes": []}}`,
+			wantClusters: []string{},
+			wantItems:    []string{},
+		},
+		"no embedded collection": {
+			body:         `{"page": {"number": 0, "size": 100, "totalElements": 0, "totalPages": 0}}`,
+			wantClusters: []string{},
+			wantItems:    []string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var clusters Paged[MdsCluster]
+			if err := json.Unmarshal([]byte(test.body), &clusters); err != nil {
+				t.Fatal(err)
+			}
+			var clusterNames []string
+			for _, cluster := range *clusters.Get() {
+				clusterNames = append(clusterNames, cluster.Name)
+			}
+			if !sameNames(clusterNames, test.wantClusters) {
+				t.Errorf("expected clusters %v, got %v", test.wantClusters, clusterNames)
+			}
+
+			var items Paged[namedItem]
+			if err := json.Unmarshal([]byte(test.body), &items); err != nil {
+				t.Fatal(err)
+			}
+			var itemNames []string
+			for _, item := range *items.Get() {
+				itemNames = append(itemNames, item.Name)
+			}
+			if !sameNames(itemNames, test.wantItems) {
+				t.Errorf("expected items %v, got %v", test.wantItems, itemNames)
+			}
+		})
+	}
+}
+
+func TestPagedGetLink(t *testing.T) {
+	var paged Paged[MdsCluster]
+	body := `{"_links": {"self": {"href": "https://mds/clusters?page=0"}, "next": {"href": ""}}}`
+	if err := json.Unmarshal([]byte(body), &paged); err != nil {
+		t.Fatal(err)
+	}
+	if link := paged.GetLink(LinkSelf); link == nil || link.Href != "https://mds/clusters?page=0" {
+		t.Errorf("expected the self link, got %v", link)
+	}
+	if link := paged.GetLink(LinkNext); link != nil {
+		t.Errorf("expected no next link for an empty href, got %v", link)
+	}
+	if link := paged.GetLink(LinkPrev); link != nil {
+		t.Errorf("expected no prev link, got %v", link)
+	}
+}
+
+func sameNames(got []string, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
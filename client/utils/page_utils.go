@@ -1,9 +1,20 @@
 package utils
 
-import "github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+import (
+	"context"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"net/url"
+	"strconv"
+)
+
+// StopFunc - Tells whether listing can stop after the given item, e.g. once the searched item is found
+type StopFunc[T any] func(item *T) bool
+
+// PageFetcher - Fetches the page currently set in the query the fetcher is bound to
+type PageFetcher[T any] func(ctx context.Context) (model.Paged[T], error)
 
 func GetNextPageInfo(page *model.PageInfo) *model.PageQuery {
-	if page.TotalPages == 0 || page.Number == page.TotalPages-1 {
+	if page.TotalPages == 0 || page.Number >= page.TotalPages-1 {
 		return nil
 	}
 	pageQuery := model.PageQuery{
@@ -12,3 +23,66 @@ func GetNextPageInfo(page *model.PageInfo) *model.PageQuery {
 	}
 	return &pageQuery
 }
+
+// GetNextPageQuery - Returns the query of the page following the given one, nil on the last page.
+// The HAL next link is followed when the server provides links, page indexes are computed otherwise.
+func GetNextPageQuery[T any](paged *model.Paged[T]) *model.PageQuery {
+	if len(paged.Links) == 0 {
+		return GetNextPageInfo(paged.GetPage())
+	}
+	next := paged.GetLink(model.LinkNext)
+	if next == nil {
+		return nil
+	}
+	nextUrl, err := url.Parse(next.Href)
+	if err != nil {
+		return GetNextPageInfo(paged.GetPage())
+	}
+	index, err := strconv.Atoi(nextUrl.Query().Get("page"))
+	if err != nil {
+		return GetNextPageInfo(paged.GetPage())
+	}
+	pageQuery := model.PageQuery{
+		Index: index,
+		Size:  paged.GetPage().Size,
+	}
+	if size, err := strconv.Atoi(nextUrl.Query().Get("size")); err == nil {
+		pageQuery.Size = size
+	}
+	return &pageQuery
+}
+
+// ForEach - Calls fn for every item of every page, starting at the page set in pageQuery.
+// pageQuery must be the PageQuery embedded in the query used by fetch, it's moved to the following
+// page after each fetch. Iteration ends at the last page, or as soon as fn returns false.
+func ForEach[T any](ctx context.Context, pageQuery *model.PageQuery, fetch PageFetcher[T], fn func(item *T) bool) error {
+	for {
+		paged, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+		items := *paged.Get()
+		for i := range items {
+			if !fn(&items[i]) {
+				return nil
+			}
+		}
+		nextPage := GetNextPageQuery(&paged)
+		// a next link pointing to the page just read would loop forever
+		if nextPage == nil || *nextPage == *pageQuery {
+			return nil
+		}
+		*pageQuery = *nextPage
+	}
+}
+
+// ListAll - Returns the items of every page, see ForEach. When stop is not nil, listing ends
+// with the first item it returns true for.
+func ListAll[T any](ctx context.Context, pageQuery *model.PageQuery, fetch PageFetcher[T], stop StopFunc[T]) ([]T, error) {
+	items := make([]T, 0)
+	err := ForEach(ctx, pageQuery, fetch, func(item *T) bool {
+		items = append(items, *item)
+		return stop == nil || !stop(item)
+	})
+	return items, err
+}
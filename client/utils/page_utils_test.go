@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"reflect"
+	"testing"
+)
+
+func TestGetNextPageQuery(t *testing.T) {
+	tests := map[string]struct {
+		links map[string]model.Link
+		page  model.PageInfo
+		want  *model.PageQuery
+	}{
+		"next link": {
+			links: map[string]model.Link{model.LinkNext: {Href: "https://mds/api/clusters?page=3&size=20&sort=name"}},
+			page:  model.PageInfo{Number: 1, Size: 10, TotalPages: 2},
+			want:  &model.PageQuery{Index: 3, Size: 20},
+		},
+		"next link without size": {
+			links: map[string]model.Link{model.LinkNext: {Href: "https://mds/api/clusters?page=2"}},
+			page:  model.PageInfo{Number: 1, Size: 10, TotalPages: 5},
+			want:  &model.PageQuery{Index: 2, Size: 10},
+		},
+		"next link with invalid size": {
+			links: map[string]model.Link{model.LinkNext: {Href: "https://mds/api/clusters?page=2&size=all"}},
+			page:  model.PageInfo{Number: 1, Size: 10, TotalPages: 5},
+			want:  &model.PageQuery{Index: 2, Size: 10},
+		},
+		"next link without page": {
+			links: map[string]model.Link{model.LinkNext: {Href: "https://mds/api/clusters?size=10"}},
+			page:  model.PageInfo{Number: 1, Size: 10, TotalPages: 5},
+			want:  &model.PageQuery{Index: 2, Size: 10},
+		},
+		"next link not an url": {
+			links: map[string]model.Link{model.LinkNext: {Href: "://mds"}},
+			page:  model.PageInfo{Number: 0, Size: 10, TotalPages: 5},
+			want:  &model.PageQuery{Index: 1, Size: 10},
+		},
+		"links without next": {
+			links: map[string]model.Link{model.LinkSelf: {Href: "https://mds/api/clusters?page=4"}},
+			page:  model.PageInfo{Number: 1, Size: 10, TotalPages: 5},
+		},
+		"no links": {
+			page: model.PageInfo{Number: 1, Size: 10, TotalPages: 5},
+			want: &model.PageQuery{Index: 2, Size: 10},
+		},
+		"no links on the last page": {
+			page: model.PageInfo{Number: 4, Size: 10, TotalPages: 5},
+		},
+		"no links nor pages": {
+			page: model.PageInfo{Size: 10},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GetNextPageQuery(&model.Paged[model.MdsCluster]{Links: test.links, Page: test.page})
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
+
+// pagesFetcher serves the pages in turn by the index of the query, recording the queries it was called with.
+type pagesFetcher struct {
+	query   model.PageQuery
+	pages   []model.Paged[model.MdsCluster]
+	fetched []model.PageQuery
+}
+
+func (f *pagesFetcher) fetch(_ context.Context) (model.Paged[model.MdsCluster], error) {
+	f.fetched = append(f.fetched, f.query)
+	if f.query.Index >= len(f.pages) || len(f.fetched) > len(f.pages)+1 {
+		return model.Paged[model.MdsCluster]{}, errors.New("no such page")
+	}
+	return f.pages[f.query.Index], nil
+}
+
+func clustersPage(number int, totalPages int, links map[string]model.Link, names ...string) model.Paged[model.MdsCluster] {
+	clusters := make([]model.MdsCluster, 0, len(names))
+	for _, name := range names {
+		clusters = append(clusters, model.MdsCluster{Name: name})
+	}
+	return model.Paged[model.MdsCluster]{
+		Embedded: map[string][]model.MdsCluster{"mdsClusterDTOes": clusters},
+		Links:    links,
+		Page:     model.PageInfo{Number: number, Size: 2, TotalPages: totalPages},
+	}
+}
+
+func nextLink(href string) map[string]model.Link {
+	return map[string]model.Link{model.LinkNext: {Href: href}}
+}
+
+func TestListAll(t *testing.T) {
+	tests := map[string]struct {
+		pages       []model.Paged[model.MdsCluster]
+		stopAt      string
+		want        []string
+		wantFetched []model.PageQuery
+		wantErr     bool
+	}{
+		"page indexes": {
+			pages: []model.Paged[model.MdsCluster]{
+				clustersPage(0, 3, nil, "a", "b"),
+				clustersPage(1, 3, nil, "c", "d"),
+				clustersPage(2, 3, nil, "e"),
+			},
+			want:        []string{"a", "b", "c", "d", "e"},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}, {Index: 1, Size: 2}, {Index: 2, Size: 2}},
+		},
+		"next links": {
+			pages: []model.Paged[model.MdsCluster]{
+				clustersPage(0, 3, nextLink("https://mds/api?page=2&size=2"), "a", "b"),
+				clustersPage(1, 3, map[string]model.Link{}, "c", "d"),
+				clustersPage(2, 3, map[string]model.Link{model.LinkSelf: {Href: "https://mds/api?page=2&size=2"}}, "e"),
+			},
+			want:        []string{"a", "b", "e"},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}, {Index: 2, Size: 2}},
+		},
+		"next link to the same page": {
+			pages: []model.Paged[model.MdsCluster]{
+				clustersPage(0, 2, nextLink("https://mds/api?page=1&size=2"), "a", "b"),
+				clustersPage(1, 2, nextLink("https://mds/api?page=1&size=2"), "c"),
+			},
+			want:        []string{"a", "b", "c"},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}, {Index: 1, Size: 2}},
+		},
+		"stop within a page": {
+			pages: []model.Paged[model.MdsCluster]{
+				clustersPage(0, 3, nil, "a", "b"),
+				clustersPage(1, 3, nil, "c", "d"),
+				clustersPage(2, 3, nil, "e"),
+			},
+			stopAt:      "c",
+			want:        []string{"a", "b", "c"},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}, {Index: 1, Size: 2}},
+		},
+		"empty": {
+			pages:       []model.Paged[model.MdsCluster]{{}},
+			want:        []string{},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}},
+		},
+		"failed fetch": {
+			pages: []model.Paged[model.MdsCluster]{
+				clustersPage(0, 3, nil, "a", "b"),
+			},
+			want:        []string{},
+			wantFetched: []model.PageQuery{{Index: 0, Size: 2}, {Index: 1, Size: 2}},
+			wantErr:     true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetcher := &pagesFetcher{query: model.PageQuery{Size: 2}, pages: test.pages}
+			var stop StopFunc[model.MdsCluster]
+			if test.stopAt != "" {
+				stop = func(cluster *model.MdsCluster) bool {
+					return cluster.Name == test.stopAt
+				}
+			}
+
+			clusters, err := ListAll(context.Background(), &fetcher.query, fetcher.fetch, stop)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got: %v", test.wantErr, err)
+			}
+			if !test.wantErr {
+				names := make([]string, 0, len(clusters))
+				for _, cluster := range clusters {
+					names = append(names, cluster.Name)
+				}
+				if !reflect.DeepEqual(names, test.want) {
+					t.Errorf("expected %v, got %v", test.want, names)
+				}
+			}
+			if !reflect.DeepEqual(fetcher.fetched, test.wantFetched) {
+				t.Errorf("expected the pages %+v fetched, got %+v", test.wantFetched, fetcher.fetched)
+			}
+		})
+	}
+}
+
+func TestForEachStopsEarly(t *testing.T) {
+	fetcher := &pagesFetcher{query: model.PageQuery{Size: 2}, pages: []model.Paged[model.MdsCluster]{
+		clustersPage(0, 2, nil, "a", "b"),
+		clustersPage(1, 2, nil, "c", "d"),
+	}}
+
+	var seen []string
+	err := ForEach(context.Background(), &fetcher.query, fetcher.fetch, func(cluster *model.MdsCluster) bool {
+		seen = append(seen, cluster.Name)
+		return cluster.Name != "a"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(seen, []string{"a"}) || len(fetcher.fetched) != 1 {
+		t.Errorf("expected to stop after the first item of the first page, saw %v in %d pages", seen, len(fetcher.fetched))
+	}
+}
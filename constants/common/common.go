@@ -16,3 +16,6 @@ const CloudAccountsId = "cloud_accounts"
 const ProviderTypesId = "provider_types"
 const TshirtSizeId = "tshirt_size"
 const CertificateId = "certificates"
+const ClusterBackupsId = "cluster_backups"
+const ServiceVersionsId = "service_versions"
+const ClusterUpgradeTargetsId = "cluster_upgrade_targets"
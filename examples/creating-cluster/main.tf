@@ -49,8 +49,4 @@ resource "vmds_cluster" "test" {
     create = "1m"
     delete = "1m"
   }
-  // non editable fields
-  lifecycle {
-    ignore_changes = [instance_size, name, cloud_provider, region,service_type]
-  }
 }
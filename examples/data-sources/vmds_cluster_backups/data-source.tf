@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vmds = {
+      source = "hashicorp.com/svc-bot-mds/vmds"
+    }
+  }
+}
+
+provider "vmds" {
+  host      = "https://console.mds.vmware.com"
+
+  username = " < Username > "
+  password = " < Password > "
+
+  type = "user_creds"
+}
+
+data "vmds_cluster_backups" "on_demand" {
+  cluster_id  = "cluster id"
+  backup_type = "ON_DEMAND"
+}
+output "resp" {
+  value = data.vmds_cluster_backups.on_demand
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vmds = {
+      source = "hashicorp.com/svc-bot-mds/vmds"
+    }
+  }
+}
+
+provider "vmds" {
+  host      = "https://console.mds.vmware.com"
+
+  username = " < Username > "
+  password = " < Password > "
+
+  type = "user_creds"
+}
+
+# latest patch of the minor version the cluster is on
+data "vmds_cluster_upgrade_targets" "patches" {
+  cluster_id     = "cluster id"
+  version_prefix = "15.4"
+}
+output "resp" {
+  value = data.vmds_cluster_upgrade_targets.patches.latest_version
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vmds = {
+      source = "hashicorp.com/svc-bot-mds/vmds"
+    }
+  }
+}
+
+provider "vmds" {
+  host      = "https://console.mds.vmware.com"
+
+  username = " < Username > "
+  password = " < Password > "
+
+  type = "user_creds"
+}
+
+data "vmds_service_versions" "postgres_15" {
+  service_type   = "POSTGRES"
+  cloud_provider = "aws"
+  version_prefix = "15"
+}
+output "resp" {
+  value = data.vmds_service_versions.postgres_15.latest_version
+}
@@ -26,8 +26,12 @@ resource "vmds_byoc_dataplane" "example" {
   certificate_id = "<<certificate id>>"
   nodepool_type = "regular"
   region = "us-east-1"
-  // non editable fields , edit is not allowed
-  lifecycle {
-    ignore_changes = [name, account_id, certificate_id, nodepool_type, region]
+  // optional, changing it upgrades the dataplane
+  version = "1.27.3"
+  timeouts = {
+    create = "90m"
+    update = "90m"
+    delete = "60m"
   }
+  // changing name, account_id, certificate_id, nodepool_type or region replaces the dataplane
 }
\ No newline at end of file
@@ -8,11 +8,67 @@ resource "vmds_cluster" "example" {
   tags               = ["mds-tf", "example"]
   dedicated = false
   shared = false
+  // upgrades and patches only on Sunday nights, Berlin time
+  maintenance_window = {
+    day_of_week = "SUNDAY"
+    start_time  = "01:00"
+    duration    = "4h"
+    timezone    = "Europe/Berlin"
+  }
+  pause_updates = false
+  timeouts = {
+    create = "45m"
+    update = "45m"
+    delete = "45m"
+  }
 
   // if cluster getting self hosted via byoc
   data_plane_id = "dataplane id"
-  // non editable fields
-  lifecycle {
-    ignore_changes = [instance_size, name, cloud_provider, region, service_type]
+}
+
+resource "vmds_cluster" "postgres" {
+  name                = "test-terraform-postgres"
+  cloud_provider      = "aws"
+  service_type        = "POSTGRES"
+  instance_size       = "XX-SMALL"
+  region              = "eu-west-1"
+  // changing the version upgrades the cluster in place, to a newer version only
+  version             = "postgres-14"
+  storage_policy_name = "storage policy name"
+  network_policy_ids  = ["policy id"]
+  tags                = ["mds-tf", "example"]
+
+  // only the block matching service_type can be set
+  cluster_metadata = {
+    postgres = {
+      username   = "admin"
+      password   = "password"
+      database   = "db"
+      extensions = ["pg_stat_statements"]
+    }
+  }
+
+  upgrade = {
+    omit_backup = false
+  }
+}
+
+resource "vmds_cluster" "mysql_restored" {
+  name                = "test-terraform-mysql-restored"
+  cloud_provider      = "aws"
+  service_type        = "MYSQL"
+  instance_size       = "XX-SMALL"
+  region              = "eu-west-1"
+  storage_policy_name = "storage policy name"
+  network_policy_ids  = ["policy id"]
+
+  // the backup must be a completed MYSQL backup, taken before the point in time
+  cluster_metadata = {
+    mysql = {
+      username              = "admin"
+      password              = "password"
+      restore_from          = "backup id"
+      restore_point_in_time = "2024-01-02T15:04:05Z"
+    }
   }
-}
\ No newline at end of file
+}
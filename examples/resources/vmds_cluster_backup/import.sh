@@ -0,0 +1,2 @@
+# Backup can be imported by specifying the cluster ID and the backup ID separated by a slash.
+terraform import vmds_cluster_backup.example s546dg29fh2ksh3dfr/b745dg29fh2ksh3xyz
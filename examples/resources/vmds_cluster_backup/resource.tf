@@ -0,0 +1,26 @@
+resource "vmds_cluster_backup" "example" {
+  cluster_id = "cluster id"
+  name       = "before-migration"
+  timeouts = {
+    create = "2h"
+  }
+}
+
+// a cluster restored from the backup once it completed
+resource "vmds_cluster" "restored" {
+  name                = "test-terraform-restored"
+  cloud_provider      = "aws"
+  service_type        = "POSTGRES"
+  instance_size       = "XX-SMALL"
+  region              = "eu-west-1"
+  storage_policy_name = "storage policy name"
+  network_policy_ids  = ["policy id"]
+
+  cluster_metadata = {
+    postgres = {
+      username     = "admin"
+      password     = "password"
+      restore_from = vmds_cluster_backup.example.id
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Backup policy can be imported by specifying the ID of its cluster.
+terraform import vmds_cluster_backup_policy.example s546dg29fh2ksh3dfr
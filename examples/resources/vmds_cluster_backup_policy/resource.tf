@@ -0,0 +1,15 @@
+resource "vmds_cluster_backup_policy" "example" {
+  cluster_id      = "cluster id"
+  cron_schedule   = "0 2 * * *"
+  retention_count = 7
+  retention_days  = 14
+  pitr_enabled    = true
+}
+
+// or a daily backup window instead of a cron schedule
+resource "vmds_cluster_backup_policy" "daily" {
+  cluster_id      = "cluster id"
+  daily_window    = "03:30"
+  retention_count = 3
+  retention_days  = 7
+}
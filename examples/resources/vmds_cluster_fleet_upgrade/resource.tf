@@ -0,0 +1,21 @@
+data "vmds_service_versions" "rabbitmq" {
+  service_type   = "RABBITMQ"
+  version_prefix = "3.11"
+}
+
+// upgrades the staging RabbitMQ clusters to the latest 3.11 patch, canary first, then two at a time
+resource "vmds_cluster_fleet_upgrade" "staging" {
+  service_type       = "RABBITMQ"
+  tags               = ["staging"]
+  target_version     = data.vmds_service_versions.rabbitmq.latest_version
+  batch_size         = 2
+  canary_cluster_ids = ["cluster id"]
+  timeouts = {
+    create = "4h"
+    update = "4h"
+  }
+}
+
+output "results" {
+  value = vmds_cluster_fleet_upgrade.staging.clusters
+}
@@ -0,0 +1,328 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/backup_status"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"time"
+)
+
+// clusterMetadataBlocks are the blocks of `cluster_metadata` by the service type they apply to.
+var clusterMetadataBlocks = map[string]string{
+	service_type.POSTGRES: "postgres",
+	service_type.MYSQL:    "mysql",
+	service_type.REDIS:    "redis",
+	service_type.RABBITMQ: "rabbitmq",
+}
+
+// clusterMetadataModel maps the service specific settings of a cluster, only the block of its service type is set.
+type clusterMetadataModel struct {
+	Postgres *postgresMetadataModel `tfsdk:"postgres"`
+	MySql    *mySqlMetadataModel    `tfsdk:"mysql"`
+	Redis    *redisMetadataModel    `tfsdk:"redis"`
+	RabbitMq *rabbitMqMetadataModel `tfsdk:"rabbitmq"`
+}
+
+type postgresMetadataModel struct {
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	Database           types.String `tfsdk:"database"`
+	RestoreFrom        types.String `tfsdk:"restore_from"`
+	RestorePointInTime types.String `tfsdk:"restore_point_in_time"`
+	Extensions         types.Set    `tfsdk:"extensions"`
+}
+
+type mySqlMetadataModel struct {
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	Database           types.String `tfsdk:"database"`
+	RestoreFrom        types.String `tfsdk:"restore_from"`
+	RestorePointInTime types.String `tfsdk:"restore_point_in_time"`
+}
+
+type redisMetadataModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+type rabbitMqMetadataModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+func clusterMetadataSchema() schema.SingleNestedAttribute {
+	credentials := func(service string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: fmt.Sprintf("Username of the %s admin user.", service),
+				Required:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: fmt.Sprintf("Password of the %s admin user.", service),
+				Required:    true,
+				Sensitive:   true,
+			},
+		}
+	}
+	database := func(service string) map[string]schema.Attribute {
+		attributes := credentials(service)
+		attributes["database"] = schema.StringAttribute{
+			Description: "Database name in the cluster.",
+			Optional:    true,
+		}
+		attributes["restore_from"] = schema.StringAttribute{
+			MarkdownDescription: "ID of the backup to restore the cluster from, e.g. `vmds_cluster_backup.<name>.id`. " +
+				"The backup must have completed, be of the same service type and not of a newer `version` than the cluster.",
+			Optional: true,
+		}
+		attributes["restore_point_in_time"] = schema.StringAttribute{
+			MarkdownDescription: "RFC 3339 time in the past to recover the data to, e.g. `2024-01-02T15:04:05Z`. " +
+				"Requires `restore_from`, of a backup taken before that time.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("restore_from")),
+			},
+		}
+		return attributes
+	}
+
+	postgres := database("Postgres")
+	postgres["extensions"] = schema.SetAttribute{
+		Description: "Set of extensions to be enabled on the cluster.",
+		Optional:    true,
+		ElementType: types.StringType,
+	}
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Service specific settings, used only once for creation. Only the block matching `service_type` can be set.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"postgres": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings of a `POSTGRES` cluster.",
+				Optional:            true,
+				Attributes:          postgres,
+			},
+			"mysql": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings of a `MYSQL` cluster.",
+				Optional:            true,
+				Attributes:          database("MySQL"),
+			},
+			"redis": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings of a `REDIS` cluster.",
+				Optional:            true,
+				Attributes:          credentials("Redis"),
+			},
+			"rabbitmq": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings of a `RABBITMQ` cluster.",
+				Optional:            true,
+				Attributes:          credentials("RabbitMQ"),
+			},
+		},
+	}
+}
+
+// request returns the create request metadata of the block matching the service type, nil when it's not set.
+func (m *clusterMetadataModel) request(ctx context.Context, serviceType string) controller.ClusterMetadata {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case serviceType == service_type.POSTGRES && m.Postgres != nil:
+		metadata := &controller.PostgresClusterMetadata{
+			Username:           m.Postgres.Username.ValueString(),
+			Password:           m.Postgres.Password.ValueString(),
+			Database:           m.Postgres.Database.ValueString(),
+			RestoreFrom:        m.Postgres.RestoreFrom.ValueString(),
+			RestorePointInTime: m.Postgres.RestorePointInTime.ValueString(),
+		}
+		m.Postgres.Extensions.ElementsAs(ctx, &metadata.Extensions, true)
+		return metadata
+	case serviceType == service_type.MYSQL && m.MySql != nil:
+		return &controller.MySqlClusterMetadata{
+			Username:           m.MySql.Username.ValueString(),
+			Password:           m.MySql.Password.ValueString(),
+			Database:           m.MySql.Database.ValueString(),
+			RestoreFrom:        m.MySql.RestoreFrom.ValueString(),
+			RestorePointInTime: m.MySql.RestorePointInTime.ValueString(),
+		}
+	case serviceType == service_type.REDIS && m.Redis != nil:
+		return &controller.RedisClusterMetadata{
+			Username: m.Redis.Username.ValueString(),
+			Password: m.Redis.Password.ValueString(),
+		}
+	case serviceType == service_type.RABBITMQ && m.RabbitMq != nil:
+		return &controller.RabbitMqClusterMetadata{
+			Username: m.RabbitMq.Username.ValueString(),
+			Password: m.RabbitMq.Password.ValueString(),
+		}
+	}
+	return nil
+}
+
+// restoreFrom returns the ID of the backup the block matching the service type restores from, empty when there's none.
+func (m *clusterMetadataModel) restoreFrom(serviceType string) string {
+	switch {
+	case m == nil:
+		return ""
+	case serviceType == service_type.POSTGRES && m.Postgres != nil:
+		return m.Postgres.RestoreFrom.ValueString()
+	case serviceType == service_type.MYSQL && m.MySql != nil:
+		return m.MySql.RestoreFrom.ValueString()
+	}
+	return ""
+}
+
+// warnClusterMetadataChange explains a changed `cluster_metadata` of an existing cluster is saved but not applied,
+// MDS takes these settings on creation only and replacing the cluster for them would lose its data.
+func warnClusterMetadataChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planValue, stateValue types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster_metadata"), &planValue)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("cluster_metadata"), &stateValue)...)
+	if resp.Diagnostics.HasError() || planValue.Equal(stateValue) {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(path.Root("cluster_metadata"), "Cluster metadata not applied",
+		"`cluster_metadata` is used only once for creation, the change is saved to the state but the cluster keeps "+
+			"the settings it was created with. Run `terraform apply -replace` on the cluster to recreate it with the new settings.",
+	)
+}
+
+// validateClusterMetadata reports any block of `cluster_metadata` which does not match the service type.
+func validateClusterMetadata(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var serviceType types.String
+	var metadata types.Object
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("service_type"), &serviceType)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("cluster_metadata"), &metadata)...)
+	if diagnostics.HasError() || serviceType.IsUnknown() || metadata.IsNull() || metadata.IsUnknown() {
+		return
+	}
+	// same as the default of service_type
+	clusterServiceType := service_type.RABBITMQ
+	if !serviceType.IsNull() {
+		clusterServiceType = serviceType.ValueString()
+	}
+
+	blocks := metadata.Attributes()
+	for _, blockServiceType := range service_type.GetAll() {
+		block := clusterMetadataBlocks[blockServiceType]
+		if blockServiceType == clusterServiceType || blocks[block] == nil || blocks[block].IsNull() {
+			continue
+		}
+		diagnostics.AddAttributeError(path.Root("cluster_metadata").AtName(block), "Invalid cluster metadata",
+			fmt.Sprintf("`%s` settings apply to %s clusters only, this cluster is of service type %s.",
+				block, blockServiceType, clusterServiceType),
+		)
+	}
+}
+
+// clusterRestorePath returns the path of the block of `cluster_metadata` a cluster of the service type
+// is restored from a backup with, false when the service type cannot be restored.
+func clusterRestorePath(serviceType string) (path.Path, bool) {
+	if serviceType != service_type.POSTGRES && serviceType != service_type.MYSQL {
+		return path.Empty(), false
+	}
+	return path.Root("cluster_metadata").AtName(clusterMetadataBlocks[serviceType]), true
+}
+
+// validateRestorePointInTime reports a restore point in time which is not an RFC 3339 time in the past.
+func validateRestorePointInTime(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	for _, serviceType := range service_type.GetAll() {
+		blockPath, ok := clusterRestorePath(serviceType)
+		if !ok {
+			continue
+		}
+		var pointInTime types.String
+		diagnostics.Append(config.GetAttribute(ctx, blockPath.AtName("restore_point_in_time"), &pointInTime)...)
+		if diagnostics.HasError() || pointInTime.IsNull() || pointInTime.IsUnknown() {
+			continue
+		}
+		target, err := time.Parse(time.RFC3339, pointInTime.ValueString())
+		if err != nil {
+			diagnostics.AddAttributeError(blockPath.AtName("restore_point_in_time"), "Invalid restore point in time",
+				fmt.Sprintf("Expected an RFC 3339 time, e.g. 2024-01-02T15:04:05Z, got: %s", pointInTime.ValueString()),
+			)
+		} else if target.After(time.Now()) {
+			diagnostics.AddAttributeError(blockPath.AtName("restore_point_in_time"), "Invalid restore point in time",
+				fmt.Sprintf("Data can only be recovered to a time in the past, %s is in the future.", pointInTime.ValueString()),
+			)
+		}
+	}
+}
+
+// validateRestore checks the backup a new cluster is restored from exists, completed and is of the service type
+// of the cluster, and that its data can be restored to the version and point in time of the plan.
+func validateRestore(ctx context.Context, client *mds.Client, plan tfsdk.Plan, diagnostics *diag.Diagnostics) {
+	var serviceType, version types.String
+	diagnostics.Append(plan.GetAttribute(ctx, path.Root("service_type"), &serviceType)...)
+	diagnostics.Append(plan.GetAttribute(ctx, path.Root("version"), &version)...)
+	if diagnostics.HasError() || serviceType.IsUnknown() {
+		return
+	}
+	blockPath, ok := clusterRestorePath(serviceType.ValueString())
+	if !ok {
+		return
+	}
+	var restoreFrom, pointInTime types.String
+	diagnostics.Append(plan.GetAttribute(ctx, blockPath.AtName("restore_from"), &restoreFrom)...)
+	diagnostics.Append(plan.GetAttribute(ctx, blockPath.AtName("restore_point_in_time"), &pointInTime)...)
+	// a backup taken in the same apply is only known once it completed
+	if diagnostics.HasError() || restoreFrom.IsNull() || restoreFrom.IsUnknown() {
+		return
+	}
+
+	backup, err := client.Controller.GetMdsBackup(ctx, restoreFrom.ValueString())
+	if core.IsNotFound(err) {
+		diagnostics.AddAttributeError(blockPath.AtName("restore_from"), "Invalid backup",
+			fmt.Sprintf("Backup [%s] does not exist.", restoreFrom.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		diagnostics.AddWarning("Validating backup",
+			"Could not fetch the backup, restoring from it is left to MDS to validate: "+err.Error(),
+		)
+		return
+	}
+	if backup.ServiceType != "" && backup.ServiceType != serviceType.ValueString() {
+		diagnostics.AddAttributeError(blockPath.AtName("restore_from"), "Invalid backup",
+			fmt.Sprintf("Backup [%s] is of a %s cluster, it cannot be restored to a %s cluster.",
+				backup.Id, backup.ServiceType, serviceType.ValueString()),
+		)
+	}
+	if backup.Status != backup_status.COMPLETED {
+		diagnostics.AddAttributeError(blockPath.AtName("restore_from"), "Invalid backup",
+			fmt.Sprintf("Backup [%s] is in status %s, only a completed backup can be restored.", backup.Id, backup.Status),
+		)
+	}
+	if !version.IsNull() && !version.IsUnknown() && backup.Version != "" && compareVersions(version.ValueString(), backup.Version) < 0 {
+		diagnostics.AddAttributeError(path.Root("version"), "Incompatible version",
+			fmt.Sprintf("Backup [%s] was taken of version %s, it cannot be restored to the older version %s.",
+				backup.Id, backup.Version, version.ValueString()),
+		)
+	}
+	if pointInTime.IsNull() || pointInTime.IsUnknown() {
+		return
+	}
+	target, err := time.Parse(time.RFC3339, pointInTime.ValueString())
+	if err != nil {
+		// reported by validateRestorePointInTime
+		return
+	}
+	if created, err := time.Parse(time.RFC3339, backup.Created); err == nil && target.Before(created) {
+		diagnostics.AddAttributeError(blockPath.AtName("restore_point_in_time"), "Invalid restore point in time",
+			fmt.Sprintf("Backup [%s] was taken at %s, data cannot be recovered to the earlier time %s.",
+				backup.Id, backup.Created, pointInTime.ValueString()),
+		)
+	}
+}
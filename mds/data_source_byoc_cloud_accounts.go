@@ -89,13 +89,12 @@ func (d *cloudAccountsDatasource) Schema(_ context.Context, _ datasource.SchemaR
 // Read refreshes the Terraform state with the latest data.
 func (d *cloudAccountsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state cloudAccountsDatasourceModel
-	var cloudAccountList []cloudAccountModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
 	query := &infra_connector.MdsCloudAccountsQuery{}
 
-	cloudAccounts, err := d.client.InfraConnector.GetCloudAccounts(query)
+	cloudAccounts, err := d.client.InfraConnector.GetAllCloudAccounts(ctx, query, nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Cloud Accounts",
@@ -104,45 +103,17 @@ func (d *cloudAccountsDatasource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
-	if cloudAccounts.Page.TotalPages > 1 {
-		for i := 1; i <= cloudAccounts.Page.TotalPages; i++ {
-			query.PageQuery.Index = i - 1
-			totalCloudAccounts, err := d.client.InfraConnector.GetCloudAccounts(query)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Read MDS Cloud Accounts",
-					err.Error(),
-				)
-				return
-			}
-
-			for _, cloudAccountDto := range *totalCloudAccounts.Get() {
-				cloudAccount := cloudAccountModel{
-					ID:          types.StringValue(cloudAccountDto.Id),
-					Name:        types.StringValue(cloudAccountDto.Name),
-					AccountType: types.StringValue(cloudAccountDto.AccountType),
-					Email:       types.StringValue(cloudAccountDto.Email),
-					OrgId:       types.StringValue(cloudAccountDto.OrgId),
-				}
-				cloudAccountList = append(cloudAccountList, cloudAccount)
-			}
-		}
-
-		tflog.Debug(ctx, "cloud accounts dto", map[string]interface{}{"dto": cloudAccountList})
-		state.CloudAccounts = append(state.CloudAccounts, cloudAccountList...)
-	} else {
-		for _, cloudAccountDto := range *cloudAccounts.Get() {
-			tflog.Info(ctx, "Converting cloud account dto")
-			cloudAccount := cloudAccountModel{
-				ID:          types.StringValue(cloudAccountDto.Id),
-				Name:        types.StringValue(cloudAccountDto.Name),
-				AccountType: types.StringValue(cloudAccountDto.AccountType),
-				Email:       types.StringValue(cloudAccountDto.Email),
-				OrgId:       types.StringValue(cloudAccountDto.OrgId),
-			}
-			tflog.Debug(ctx, "converted cloud Account dto", map[string]interface{}{"dto": cloudAccount})
-			state.CloudAccounts = append(state.CloudAccounts, cloudAccount)
+	for _, cloudAccountDto := range cloudAccounts {
+		tflog.Info(ctx, "Converting cloud account dto")
+		cloudAccount := cloudAccountModel{
+			ID:          types.StringValue(cloudAccountDto.Id),
+			Name:        types.StringValue(cloudAccountDto.Name),
+			AccountType: types.StringValue(cloudAccountDto.AccountType),
+			Email:       types.StringValue(cloudAccountDto.Email),
+			OrgId:       types.StringValue(cloudAccountDto.OrgId),
 		}
+		tflog.Debug(ctx, "converted cloud Account dto", map[string]interface{}{"dto": cloudAccount})
+		state.CloudAccounts = append(state.CloudAccounts, cloudAccount)
 	}
 	state.Id = types.StringValue(common.DataSource + common.CloudAccountsId)
 	// Set state
@@ -93,7 +93,7 @@ func (d *cloudProviderRegionsDataSource) Read(ctx context.Context, req datasourc
 	//Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
-	regions, err := d.client.InfraConnector.GetDataPlaneRegions()
+	regions, err := d.client.InfraConnector.GetDataPlaneRegions(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Regions:",
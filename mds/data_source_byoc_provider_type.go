@@ -68,7 +68,7 @@ func (d *providerTypesDataSource) Read(ctx context.Context, req datasource.ReadR
 	//Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	tflog.Info(ctx, "getProviderTypes")
-	typesList, err := d.client.InfraConnector.GetProviderTypes()
+	typesList, err := d.client.InfraConnector.GetProviderTypes(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Provider Types:",
@@ -95,7 +95,7 @@ func (d *tshirtSizeDatasource) Read(ctx context.Context, req datasource.ReadRequ
 
 	query := &infra_connector.MdsTshirtSizesQuery{}
 
-	tshirtSizes, err := d.client.InfraConnector.GetTshirtSizes(query)
+	tshirtSizes, err := d.client.InfraConnector.GetAllTshirtSizes(ctx, query, nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read BYOC Tshirt sizes",
@@ -104,7 +104,7 @@ func (d *tshirtSizeDatasource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	for _, cloudAccountDto := range *tshirtSizes.Get() {
+	for _, cloudAccountDto := range tshirtSizes {
 		tflog.Info(ctx, "Converting tshirt size dto")
 		tshirt := tshirtSizeModel{
 			Nodes:    types.Int64Value(cloudAccountDto.Nodes),
@@ -88,13 +88,12 @@ func (d *certificatesDatasource) Schema(_ context.Context, _ datasource.SchemaRe
 // Read refreshes the Terraform state with the latest data.
 func (d *certificatesDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state certificatesDatasourceModel
-	var certificateList []certificatesModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
 	query := &infra_connector.MDSCertificateQuery{}
 
-	certificates, err := d.client.InfraConnector.GetCertificates(query)
+	certificates, err := d.client.InfraConnector.GetAllCertificates(ctx, query, nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Byoc Certificates",
@@ -103,45 +102,17 @@ func (d *certificatesDatasource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	if certificates.Page.TotalPages > 1 {
-		for i := 1; i <= certificates.Page.TotalPages; i++ {
-			query.PageQuery.Index = i - 1
-			totalCertificates, err := d.client.InfraConnector.GetCertificates(query)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Read Byoc certificates",
-					err.Error(),
-				)
-				return
-			}
-
-			for _, certificateDto := range *totalCertificates.Get() {
-				certificate := certificatesModel{
-					ID:           types.StringValue(certificateDto.Id),
-					Name:         types.StringValue(certificateDto.Name),
-					DomainName:   types.StringValue(certificateDto.DomainName),
-					ProviderType: types.StringValue(certificateDto.Provider),
-					ExpiryTime:   types.StringValue(certificateDto.ExpiryTime),
-				}
-				certificateList = append(certificateList, certificate)
-			}
-		}
-
-		tflog.Debug(ctx, "certificates dto", map[string]interface{}{"dto": certificateList})
-		state.Certificates = append(state.Certificates, certificateList...)
-	} else {
-		for _, certificateDto := range *certificates.Get() {
-			tflog.Info(ctx, "Converting certificate Dto1", map[string]interface{}{"dto": certificateDto})
-			certificate := certificatesModel{
-				ID:           types.StringValue(certificateDto.Id),
-				Name:         types.StringValue(certificateDto.Name),
-				DomainName:   types.StringValue(certificateDto.DomainName),
-				ProviderType: types.StringValue(certificateDto.Provider),
-				ExpiryTime:   types.StringValue(certificateDto.ExpiryTime),
-			}
-			tflog.Info(ctx, "converted certificate Dto", map[string]interface{}{"dto": certificate})
-			state.Certificates = append(state.Certificates, certificate)
+	for _, certificateDto := range certificates {
+		tflog.Info(ctx, "Converting certificate Dto1", map[string]interface{}{"dto": certificateDto})
+		certificate := certificatesModel{
+			ID:           types.StringValue(certificateDto.Id),
+			Name:         types.StringValue(certificateDto.Name),
+			DomainName:   types.StringValue(certificateDto.DomainName),
+			ProviderType: types.StringValue(certificateDto.Provider),
+			ExpiryTime:   types.StringValue(certificateDto.ExpiryTime),
 		}
+		tflog.Info(ctx, "converted certificate Dto", map[string]interface{}{"dto": certificate})
+		state.Certificates = append(state.Certificates, certificate)
 	}
 	state.Id = types.StringValue(common.DataSource + common.CertificateId)
 
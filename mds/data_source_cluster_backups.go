@@ -0,0 +1,167 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/backup_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/constants/common"
+)
+
+var (
+	_ datasource.DataSource              = &clusterBackupsDatasource{}
+	_ datasource.DataSourceWithConfigure = &clusterBackupsDatasource{}
+)
+
+// clusterBackupsDatasourceModel maps the data source schema data.
+type clusterBackupsDatasourceModel struct {
+	Id         types.String         `tfsdk:"id"`
+	ClusterId  types.String         `tfsdk:"cluster_id"`
+	BackupType types.String         `tfsdk:"backup_type"`
+	Backups    []clusterBackupModel `tfsdk:"backups"`
+}
+
+type clusterBackupModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Version types.String `tfsdk:"version"`
+	Size    types.String `tfsdk:"size"`
+	Status  types.String `tfsdk:"status"`
+	Created types.String `tfsdk:"created"`
+}
+
+// NewClusterBackupsDatasource is a helper function to simplify the provider implementation.
+func NewClusterBackupsDatasource() datasource.DataSource {
+	return &clusterBackupsDatasource{}
+}
+
+// clusterBackupsDatasource is the data source implementation.
+type clusterBackupsDatasource struct {
+	client *mds.Client
+}
+
+// Metadata returns the data source type name.
+func (d *clusterBackupsDatasource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_backups"
+}
+
+// Schema defines the schema for the data source.
+func (d *clusterBackupsDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Used to fetch all backups of a cluster on MDS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The testing framework requires an id attribute to be present in every data source and resource",
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster to fetch the backups of.",
+				Required:    true,
+			},
+			"backup_type": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Type of the backups to fetch, one of `%s` and `%s`. All types are fetched when not set.",
+					backup_type.ON_DEMAND, backup_type.SCHEDULED),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(backup_type.GetAll()...),
+				},
+			},
+			"backups": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the backup.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the backup.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the backup.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "Version of the backed up cluster.",
+							Computed:    true,
+						},
+						"size": schema.StringAttribute{
+							Description: "Size of the backup.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the backup.",
+							Computed:    true,
+						},
+						"created": schema.StringAttribute{
+							Description: "Creation time of the backup.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *clusterBackupsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state clusterBackupsDatasourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := &controller.MdsClusterBackupsQuery{
+		BackupType: state.BackupType.ValueString(),
+	}
+
+	backups, err := d.client.Controller.GetAllMdsClusterBackups(ctx, state.ClusterId.ValueString(), query, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read MDS Cluster Backups",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Backups = []clusterBackupModel{}
+	for _, backupDto := range backups {
+		state.Backups = append(state.Backups, clusterBackupModel{
+			ID:      types.StringValue(backupDto.Id),
+			Name:    types.StringValue(backupDto.Name),
+			Type:    types.StringValue(backupDto.BackupType),
+			Version: types.StringValue(backupDto.Version),
+			Size:    types.StringValue(backupDto.Size),
+			Status:  types.StringValue(backupDto.Status),
+			Created: types.StringValue(backupDto.Created),
+		})
+	}
+	state.Id = types.StringValue(common.DataSource + common.ClusterBackupsId)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *clusterBackupsDatasource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*mds.Client)
+}
@@ -167,7 +167,7 @@ func (d *clusterMetadataDataSource) Read(ctx context.Context, req datasource.Rea
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-	clusterMetadata, err := d.client.Controller.GetMdsClusterMetaData(state.Id.ValueString())
+	clusterMetadata, err := d.client.Controller.GetMdsClusterMetaData(ctx, state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Cluster Metadata",
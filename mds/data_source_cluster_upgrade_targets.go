@@ -0,0 +1,129 @@
+package mds
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/constants/common"
+)
+
+var (
+	_ datasource.DataSource              = &clusterUpgradeTargetsDatasource{}
+	_ datasource.DataSourceWithConfigure = &clusterUpgradeTargetsDatasource{}
+)
+
+// clusterUpgradeTargetsDatasourceModel maps the data source schema data.
+type clusterUpgradeTargetsDatasourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	ClusterId      types.String   `tfsdk:"cluster_id"`
+	VersionPrefix  types.String   `tfsdk:"version_prefix"`
+	CurrentVersion types.String   `tfsdk:"current_version"`
+	LatestVersion  types.String   `tfsdk:"latest_version"`
+	TargetVersions []types.String `tfsdk:"target_versions"`
+}
+
+// NewClusterUpgradeTargetsDatasource is a helper function to simplify the provider implementation.
+func NewClusterUpgradeTargetsDatasource() datasource.DataSource {
+	return &clusterUpgradeTargetsDatasource{}
+}
+
+// clusterUpgradeTargetsDatasource is the data source implementation.
+type clusterUpgradeTargetsDatasource struct {
+	client *mds.Client
+}
+
+// Metadata returns the data source type name.
+func (d *clusterUpgradeTargetsDatasource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_upgrade_targets"
+}
+
+// Schema defines the schema for the data source.
+func (d *clusterUpgradeTargetsDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Used to fetch the versions a cluster on MDS can be upgraded to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The testing framework requires an id attribute to be present in every data source and resource",
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster to fetch the upgrade targets of.",
+				Required:    true,
+			},
+			"version_prefix": schema.StringAttribute{
+				MarkdownDescription: "Fetches only the versions starting with these numbers, e.g. `15.4` fetches `15.4.2` but not `15.5.0`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(versionPrefixPattern, "must be the leading numbers of a version, e.g. 15 or 15.4"),
+				},
+			},
+			"current_version": schema.StringAttribute{
+				Description: "Version the cluster is running.",
+				Computed:    true,
+			},
+			"latest_version": schema.StringAttribute{
+				MarkdownDescription: "Newest of the fetched versions which isn't a pre-release, e.g. the latest patch when `version_prefix` " +
+					"sets the major and minor version of the cluster. Empty when there's none.",
+				Computed: true,
+			},
+			"target_versions": schema.ListAttribute{
+				Description: "Versions the cluster can be upgraded to.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *clusterUpgradeTargetsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state clusterUpgradeTargetsDatasourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgradeTargets, err := d.client.UpgradeService.GetMdsClusterUpgradeTargets(ctx, state.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read MDS Cluster Upgrade Targets",
+			err.Error(),
+		)
+		return
+	}
+
+	state.CurrentVersion = types.StringValue(upgradeTargets.CurrentVersion)
+	state.TargetVersions = []types.String{}
+	var versions []string
+	for _, version := range upgradeTargets.TargetVersions {
+		if !state.VersionPrefix.IsNull() && !versionHasPrefix(version, state.VersionPrefix.ValueString()) {
+			continue
+		}
+		state.TargetVersions = append(state.TargetVersions, types.StringValue(version))
+		versions = append(versions, version)
+	}
+	state.LatestVersion = types.StringValue(latestVersion(versions))
+	state.Id = types.StringValue(common.DataSource + common.ClusterUpgradeTargetsId)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *clusterUpgradeTargetsDatasource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*mds.Client)
+}
@@ -81,7 +81,6 @@ func (d *clustersDatasource) Schema(_ context.Context, _ datasource.SchemaReques
 // Read refreshes the Terraform state with the latest data.
 func (d *clustersDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state clustersDatasourceModel
-	var clusterList []clustersModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
@@ -89,7 +88,7 @@ func (d *clustersDatasource) Read(ctx context.Context, req datasource.ReadReques
 		ServiceType: state.ServiceType.ValueString(),
 	}
 
-	clusters, err := d.client.Controller.GetMdsClusters(query)
+	clusters, err := d.client.Controller.GetAllMdsClusters(ctx, query, nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Clusters",
@@ -98,38 +97,13 @@ func (d *clustersDatasource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	if clusters.Page.TotalPages > 1 {
-		for i := 1; i <= clusters.Page.TotalPages; i++ {
-			query.PageQuery.Index = i - 1
-			totalClusters, err := d.client.Controller.GetMdsClusters(query)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Read MDS Clusters",
-					err.Error(),
-				)
-				return
-			}
-
-			for _, mdsClusterDto := range *totalClusters.Get() {
-				cluster := clustersModel{
-					ID:   types.StringValue(mdsClusterDto.ID),
-					Name: types.StringValue(mdsClusterDto.Name),
-				}
-				clusterList = append(clusterList, cluster)
-			}
-		}
-
-		tflog.Debug(ctx, "rabbitmq dto", map[string]interface{}{"dto": clusterList})
-		state.Clusters = append(state.Clusters, clusterList...)
-	} else {
-		for _, mdsClusterDto := range *clusters.Get() {
-			cluster := clustersModel{
-				ID:   types.StringValue(mdsClusterDto.ID),
-				Name: types.StringValue(mdsClusterDto.Name),
-			}
-			tflog.Debug(ctx, "mdsClusterDto dto", map[string]interface{}{"dto": cluster})
-			state.Clusters = append(state.Clusters, cluster)
+	for _, mdsClusterDto := range clusters {
+		cluster := clustersModel{
+			ID:   types.StringValue(mdsClusterDto.ID),
+			Name: types.StringValue(mdsClusterDto.Name),
 		}
+		tflog.Debug(ctx, "mdsClusterDto dto", map[string]interface{}{"dto": cluster})
+		state.Clusters = append(state.Clusters, cluster)
 	}
 
 	state.ID = types.StringValue(common.DataSource + common.ClusterId)
@@ -144,7 +144,7 @@ func (d *instanceTypesDataSource) Read(ctx context.Context, req datasource.ReadR
 	query := &controller.MdsInstanceTypesQuery{
 		ServiceType: state.ServiceType.ValueString(),
 	}
-	serviceInstanceTypes, err := d.client.Controller.GetServiceInstanceTypes(query)
+	serviceInstanceTypes, err := d.client.Controller.GetServiceInstanceTypes(ctx, query)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
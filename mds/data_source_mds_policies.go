@@ -82,7 +82,6 @@ func (d *mdsPoliciesDatasource) Schema(_ context.Context, _ datasource.SchemaReq
 // Read refreshes the Terraform state with the latest data.
 func (d *mdsPoliciesDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state mdsPoliciesDatasourceModel
-	var policies []mdsPoliciesModel
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
@@ -91,7 +90,7 @@ func (d *mdsPoliciesDatasource) Read(ctx context.Context, req datasource.ReadReq
 		Type: policy_type.RABBITMQ,
 	}
 
-	nwPolicies, err := d.client.CustomerMetadata.GetPolicies(query)
+	nwPolicies, err := d.client.CustomerMetadata.GetAllPolicies(ctx, query, nil)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Policies",
@@ -100,38 +99,13 @@ func (d *mdsPoliciesDatasource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	if nwPolicies.Page.TotalPages > 1 {
-		for i := 1; i <= nwPolicies.Page.TotalPages; i++ {
-			query.PageQuery.Index = i - 1
-			totalPolicies, err := d.client.CustomerMetadata.GetPolicies(query)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Unable to Read MDS Policies",
-					err.Error(),
-				)
-				return
-			}
-
-			for _, mdsPolicyDTO := range *totalPolicies.Get() {
-				policy := mdsPoliciesModel{
-					ID:   types.StringValue(mdsPolicyDTO.ID),
-					Name: types.StringValue(mdsPolicyDTO.Name),
-				}
-				policies = append(policies, policy)
-			}
-		}
-
-		tflog.Debug(ctx, "rabbitmq dto", map[string]interface{}{"dto": policies})
-		state.Policies = append(state.Policies, policies...)
-	} else {
-		for _, mdsPolicyDTO := range *nwPolicies.Get() {
-			policy := mdsPoliciesModel{
-				ID:   types.StringValue(mdsPolicyDTO.ID),
-				Name: types.StringValue(mdsPolicyDTO.Name),
-			}
-			tflog.Debug(ctx, "rabbitmq dto", map[string]interface{}{"dto": policy})
-			state.Policies = append(state.Policies, policy)
+	for _, mdsPolicyDTO := range nwPolicies {
+		policy := mdsPoliciesModel{
+			ID:   types.StringValue(mdsPolicyDTO.ID),
+			Name: types.StringValue(mdsPolicyDTO.Name),
 		}
+		tflog.Debug(ctx, "rabbitmq dto", map[string]interface{}{"dto": policy})
+		state.Policies = append(state.Policies, policy)
 	}
 
 	state.Id = types.StringValue(common.DataSource + common.PoliciesId)
@@ -83,7 +83,7 @@ func (d *networkPortsDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
-	networkPorts, err := d.client.ServiceMetadata.GetNetworkPorts()
+	networkPorts, err := d.client.ServiceMetadata.GetNetworkPorts(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS InstanceTypes",
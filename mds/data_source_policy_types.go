@@ -68,7 +68,7 @@ func (d *policyTypesDataSource) Read(ctx context.Context, req datasource.ReadReq
 	//Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	tflog.Info(ctx, "getPolicyTypes")
-	typesList, err := d.client.ServiceMetadata.GetPolicyTypes()
+	typesList, err := d.client.ServiceMetadata.GetPolicyTypes(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Policy Types:",
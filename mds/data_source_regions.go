@@ -150,7 +150,7 @@ func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	var typeDetail model.MdsInstanceType
 	if !state.InstanceSize.IsNull() {
-		instanceTypes, err := d.client.Controller.GetServiceInstanceTypes(&controller.MdsInstanceTypesQuery{
+		instanceTypes, err := d.client.Controller.GetServiceInstanceTypes(ctx, &controller.MdsInstanceTypesQuery{
 			ServiceType: service_type.RABBITMQ,
 		})
 		if err != nil {
@@ -174,7 +174,7 @@ func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	if state.DedicatedDataPlane.ValueBool() {
 		regionQuery.OrgId = d.client.Root.OrgId
 	}
-	regions, err := d.client.InfraConnector.GetRegionsWithDataPlanes(regionQuery)
+	regions, err := d.client.InfraConnector.GetRegionsWithDataPlanes(ctx, regionQuery)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Regions:",
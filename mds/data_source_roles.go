@@ -86,7 +86,7 @@ func (d *rolesDatasource) Read(ctx context.Context, req datasource.ReadRequest,
 	query := &service_metadata.MDSRolesQuery{
 		Type: role_type.MDS,
 	}
-	rolesResponse, err := d.client.ServiceMetadata.GetMdsRoles(query)
+	rolesResponse, err := d.client.ServiceMetadata.GetMdsRoles(ctx, query)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS roles",
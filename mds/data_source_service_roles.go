@@ -111,7 +111,7 @@ func (d *serviceRolesDatasource) Read(ctx context.Context, req datasource.ReadRe
 	query := &service_metadata.MDSRolesQuery{
 		Type: state.Type.ValueString(),
 	}
-	rolesResponse, err := d.client.ServiceMetadata.GetMdsServiceRoles(query)
+	rolesResponse, err := d.client.ServiceMetadata.GetMdsServiceRoles(ctx, query)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read MDS Service roles",
@@ -0,0 +1,163 @@
+package mds
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/constants/common"
+	"strings"
+)
+
+var (
+	_ datasource.DataSource              = &serviceVersionsDatasource{}
+	_ datasource.DataSourceWithConfigure = &serviceVersionsDatasource{}
+)
+
+// serviceVersionsDatasourceModel maps the data source schema data.
+type serviceVersionsDatasourceModel struct {
+	Id            types.String          `tfsdk:"id"`
+	ServiceType   types.String          `tfsdk:"service_type"`
+	Provider      types.String          `tfsdk:"cloud_provider"`
+	VersionPrefix types.String          `tfsdk:"version_prefix"`
+	LatestVersion types.String          `tfsdk:"latest_version"`
+	Versions      []serviceVersionModel `tfsdk:"versions"`
+}
+
+type serviceVersionModel struct {
+	Version types.String `tfsdk:"version"`
+	Status  types.String `tfsdk:"status"`
+	Default types.Bool   `tfsdk:"default"`
+}
+
+// NewServiceVersionsDatasource is a helper function to simplify the provider implementation.
+func NewServiceVersionsDatasource() datasource.DataSource {
+	return &serviceVersionsDatasource{}
+}
+
+// serviceVersionsDatasource is the data source implementation.
+type serviceVersionsDatasource struct {
+	client *mds.Client
+}
+
+// Metadata returns the data source type name.
+func (d *serviceVersionsDatasource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_versions"
+}
+
+// Schema defines the schema for the data source.
+func (d *serviceVersionsDatasource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Used to fetch the versions of a service which clusters can be created with on MDS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The testing framework requires an id attribute to be present in every data source and resource",
+			},
+			"service_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the service to fetch the versions of, one of `" + strings.Join(service_type.GetAll(), "`, `") + "`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(service_type.GetAll()...),
+				},
+			},
+			"cloud_provider": schema.StringAttribute{
+				MarkdownDescription: "Cloud provider to fetch the versions available on, e.g. `aws`. Versions of all providers are fetched when not set.",
+				Optional:            true,
+			},
+			"version_prefix": schema.StringAttribute{
+				MarkdownDescription: "Fetches only the versions starting with these numbers, e.g. `15.4` fetches `15.4.0` and `15.4.2` but not `15.5.0`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(versionPrefixPattern, "must be the leading numbers of a version, e.g. 15 or 15.4"),
+				},
+			},
+			"latest_version": schema.StringAttribute{
+				MarkdownDescription: "Newest of the fetched versions which isn't a pre-release, e.g. the latest patch when `version_prefix` " +
+					"sets the major and minor version. Empty when there's none.",
+				Computed: true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							Description: "Version of the service.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the version.",
+							Computed:    true,
+						},
+						"default": schema.BoolAttribute{
+							Description: "Whether clusters are created with this version when none is given.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *serviceVersionsDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state serviceVersionsDatasourceModel
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := &controller.MdsServiceVersionsQuery{
+		ServiceType: state.ServiceType.ValueString(),
+		Provider:    state.Provider.ValueString(),
+	}
+
+	serviceVersions, err := d.client.Controller.GetServiceVersions(ctx, query)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read MDS Service Versions",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Versions = []serviceVersionModel{}
+	var versions []string
+	for _, versionDto := range serviceVersions.Versions {
+		if !state.VersionPrefix.IsNull() && !versionHasPrefix(versionDto.Version, state.VersionPrefix.ValueString()) {
+			continue
+		}
+		state.Versions = append(state.Versions, serviceVersionModel{
+			Version: types.StringValue(versionDto.Version),
+			Status:  types.StringValue(versionDto.Status),
+			Default: types.BoolValue(versionDto.IsDefault),
+		})
+		versions = append(versions, versionDto.Version)
+	}
+	state.LatestVersion = types.StringValue(latestVersion(versions))
+	state.Id = types.StringValue(common.DataSource + common.ServiceVersionsId)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *serviceVersionsDatasource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*mds.Client)
+}
@@ -0,0 +1,41 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+)
+
+// addApiError reports err with the given summary and detail. Validation errors MDS sends for request fields
+// listed in fieldPaths (keyed by the JSON name of the field) are attached to the matching attribute, so that
+// Terraform points at the offending configuration. Any other error is reported as a whole.
+func addApiError(diagnostics *diag.Diagnostics, summary string, detail string, err error, fieldPaths map[string]path.Path) {
+	fieldErrors := core.FieldErrorsOf(err)
+	unmatched := len(fieldErrors) == 0
+	for _, fieldError := range fieldErrors {
+		attributePath, ok := fieldPaths[fieldError.Field]
+		if !ok {
+			unmatched = true
+			continue
+		}
+		diagnostics.AddAttributeError(attributePath, summary, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+	if unmatched {
+		diagnostics.AddError(summary, detail+err.Error())
+	}
+}
+
+// removeIfNotFound removes the resource from state when err tells MDS does not know it anymore, e.g. as it was
+// deleted outside of Terraform, so that the next plan creates it again. It returns whether the resource was removed.
+func removeIfNotFound(ctx context.Context, err error, state *tfsdk.State, id string) bool {
+	if !core.IsNotFound(err) {
+		return false
+	}
+	tflog.Warn(ctx, "Resource not found in MDS, removing it from state", map[string]interface{}{"id": id})
+	state.RemoveResource(ctx)
+	return true
+}
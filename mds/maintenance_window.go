@@ -0,0 +1,239 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"strings"
+	"time"
+	// time zones are resolved the same whichever system the provider runs on
+	_ "time/tzdata"
+)
+
+const maxMaintenanceDuration = 24 * time.Hour
+
+var maintenanceFieldPaths = map[string]path.Path{
+	"maintenanceStartTime": path.Root("maintenance_window"),
+	"maintenanceEndTime":   path.Root("maintenance_window").AtName("duration"),
+	"pauseUpdates":         path.Root("pause_updates"),
+}
+
+var daysOfWeek = []string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}
+
+// maintenanceWindowModel maps the weekly window upgrades and patches of a cluster are applied in.
+type maintenanceWindowModel struct {
+	DayOfWeek types.String `tfsdk:"day_of_week"`
+	StartTime types.String `tfsdk:"start_time"`
+	Duration  types.String `tfsdk:"duration"`
+	Timezone  types.String `tfsdk:"timezone"`
+}
+
+func maintenanceWindowSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Weekly window upgrades and patches of the cluster are applied in. MDS picks the time when not set, " +
+			"removing the block lets MDS pick it again.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"day_of_week": schema.StringAttribute{
+				MarkdownDescription: "Day the window starts on, one of `" + strings.Join(daysOfWeek, "`, `") + "`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(daysOfWeek...),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Time of day the window starts at, as `HH:MM`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day as HH:MM"),
+				},
+			},
+			"duration": schema.StringAttribute{
+				MarkdownDescription: "Length of the window, e.g. `4h` or `1h30m`, of 24 hours at most.",
+				Required:            true,
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone of `start_time`, e.g. `Europe/Berlin`. Defaults to `UTC`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validateMaintenanceWindow reports a duration or time zone of `maintenance_window` which cannot be parsed.
+func validateMaintenanceWindow(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var windowObject types.Object
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("maintenance_window"), &windowObject)...)
+	if diagnostics.HasError() || windowObject.IsNull() || windowObject.IsUnknown() {
+		return
+	}
+	var window maintenanceWindowModel
+	diagnostics.Append(windowObject.As(ctx, &window, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return
+	}
+	if !window.Duration.IsNull() && !window.Duration.IsUnknown() {
+		duration, err := time.ParseDuration(window.Duration.ValueString())
+		if err != nil || duration <= 0 || duration > maxMaintenanceDuration {
+			diagnostics.AddAttributeError(path.Root("maintenance_window").AtName("duration"), "Invalid maintenance window",
+				fmt.Sprintf("Expected a duration of up to %s, e.g. 4h, got: %s", formatDuration(maxMaintenanceDuration), window.Duration.ValueString()),
+			)
+		}
+	}
+	if !window.Timezone.IsUnknown() {
+		if _, err := window.location(); err != nil {
+			diagnostics.AddAttributeError(path.Root("maintenance_window").AtName("timezone"), "Invalid maintenance window",
+				fmt.Sprintf("Unknown time zone %s: %s", window.Timezone.ValueString(), err.Error()),
+			)
+		}
+	}
+}
+
+func (w *maintenanceWindowModel) location() (*time.Location, error) {
+	if w == nil || w.Timezone.IsNull() {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone.ValueString())
+}
+
+// maintenanceRequest returns the request setting the window to its next occurrence after now, a nil window removes it.
+func maintenanceRequest(window *maintenanceWindowModel, pauseUpdates types.Bool, now time.Time) (*controller.MdsClusterMaintenanceUpdateRequest, error) {
+	request := &controller.MdsClusterMaintenanceUpdateRequest{
+		PauseUpdates: pauseUpdates.ValueBool(),
+	}
+	if window == nil {
+		return request, nil
+	}
+	location, err := window.location()
+	if err != nil {
+		return nil, err
+	}
+	startTime, err := time.Parse("15:04", window.StartTime.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(window.Duration.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	weekday := time.Monday
+	for i, day := range daysOfWeek {
+		if day == window.DayOfWeek.ValueString() {
+			weekday = time.Weekday((i + 1) % 7)
+		}
+	}
+
+	now = now.In(location)
+	start := time.Date(now.Year(), now.Month(), now.Day(), startTime.Hour(), startTime.Minute(), 0, 0, location)
+	start = start.AddDate(0, 0, (int(weekday)-int(now.Weekday())+7)%7)
+	if start.Before(now) {
+		start = start.AddDate(0, 0, 7)
+	}
+	request.MaintenanceStartTime = start.UnixMilli()
+	request.MaintenanceEndTime = start.Add(duration).UnixMilli()
+	return request, nil
+}
+
+// maintenanceChanged tells whether the plan changes the window or pausing of updates of the cluster.
+func maintenanceChanged(plan *clusterResourceModel, state *clusterResourceModel) bool {
+	if !plan.PauseUpdates.Equal(state.PauseUpdates) {
+		return true
+	}
+	if plan.MaintenanceWindow == nil || state.MaintenanceWindow == nil {
+		return plan.MaintenanceWindow != state.MaintenanceWindow
+	}
+	return *plan.MaintenanceWindow != *state.MaintenanceWindow
+}
+
+// saveMaintenanceFromResponse sets the window and pausing of updates of the cluster to the state, the window in the
+// time zone of the state. Like dedicated and shared, unset ones stay unset as long as MDS reports the defaults.
+func saveMaintenanceFromResponse(state *clusterResourceModel, cluster *model.MdsCluster) {
+	if !state.PauseUpdates.IsNull() || cluster.PauseUpdates {
+		state.PauseUpdates = types.BoolValue(cluster.PauseUpdates)
+	}
+	if state.MaintenanceWindow == nil {
+		return
+	}
+	if cluster.MaintenanceStartTime == 0 {
+		state.MaintenanceWindow = nil
+		return
+	}
+	location, err := state.MaintenanceWindow.location()
+	if err != nil {
+		location = time.UTC
+	}
+	start := time.UnixMilli(cluster.MaintenanceStartTime).In(location)
+	duration := time.UnixMilli(cluster.MaintenanceEndTime).Sub(start)
+
+	window := &maintenanceWindowModel{
+		DayOfWeek: types.StringValue(strings.ToUpper(start.Weekday().String())),
+		StartTime: types.StringValue(start.Format("15:04")),
+		Duration:  types.StringValue(formatDuration(duration)),
+		Timezone:  state.MaintenanceWindow.Timezone,
+	}
+	// MDS repeats the window every 7 days of 24 hours, so once daylight saving time starts or ends the start
+	// it reports is off by the shift of the clocks, while the configured day and time still hold
+	if state.MaintenanceWindow.occursAt(start, clockShift(location, start)) {
+		window.DayOfWeek = state.MaintenanceWindow.DayOfWeek
+		window.StartTime = state.MaintenanceWindow.StartTime
+	}
+	// a duration spelled differently, e.g. 90m for 1h30m, is no change
+	if stateDuration, err := time.ParseDuration(state.MaintenanceWindow.Duration.ValueString()); err == nil && stateDuration == duration {
+		window.Duration = state.MaintenanceWindow.Duration
+	}
+	state.MaintenanceWindow = window
+}
+
+// occursAt tells whether the window starts at the time, give or take the margin.
+func (w *maintenanceWindowModel) occursAt(start time.Time, margin time.Duration) bool {
+	startTime, err := time.Parse("15:04", w.StartTime.ValueString())
+	if err != nil {
+		return false
+	}
+	// the window may start the day before or after once shifted across midnight
+	for _, days := range []int{-1, 0, 1} {
+		day := start.AddDate(0, 0, days)
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, start.Location())
+		if strings.ToUpper(occurrence.Weekday().String()) != w.DayOfWeek.ValueString() {
+			continue
+		}
+		if difference := occurrence.Sub(start); difference <= margin && difference >= -margin {
+			return true
+		}
+	}
+	return false
+}
+
+// clockShift returns how far the clocks of the location move for daylight saving time in the year of the time,
+// 0 in a location without it.
+func clockShift(location *time.Location, at time.Time) time.Duration {
+	// winter and summer of either hemisphere
+	_, januaryOffset := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, location).Zone()
+	_, julyOffset := time.Date(at.Year(), time.July, 1, 0, 0, 0, 0, location).Zone()
+	shift := time.Duration(julyOffset-januaryOffset) * time.Second
+	if shift < 0 {
+		return -shift
+	}
+	return shift
+}
+
+// formatDuration spells a duration without its zero units, e.g. `4h` rather than `4h0m0s`.
+func formatDuration(duration time.Duration) string {
+	formatted := duration.String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	return formatted
+}
@@ -0,0 +1,47 @@
+package mds
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"testing"
+	"time"
+)
+
+func TestSaveMaintenanceFromResponse(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	tests := map[string]struct {
+		timezone      types.String
+		start         time.Time
+		wantDayOfWeek string
+		wantStartTime string
+	}{
+		"same time":                 {timezone: types.StringValue("Europe/Berlin"), start: time.Date(2025, 1, 6, 3, 0, 0, 0, berlin), wantDayOfWeek: "MONDAY", wantStartTime: "03:00"},
+		"repeated into summer time": {timezone: types.StringValue("Europe/Berlin"), start: time.Date(2025, 4, 7, 2, 0, 0, 0, time.UTC), wantDayOfWeek: "MONDAY", wantStartTime: "03:00"},
+		"repeated into winter time": {timezone: types.StringValue("Europe/Berlin"), start: time.Date(2025, 11, 3, 3, 0, 0, 0, time.UTC), wantDayOfWeek: "MONDAY", wantStartTime: "03:00"},
+		"changed time":              {timezone: types.StringValue("Europe/Berlin"), start: time.Date(2025, 1, 6, 5, 0, 0, 0, berlin), wantDayOfWeek: "MONDAY", wantStartTime: "05:00"},
+		"changed day":               {timezone: types.StringValue("Europe/Berlin"), start: time.Date(2025, 1, 7, 3, 0, 0, 0, berlin), wantDayOfWeek: "TUESDAY", wantStartTime: "03:00"},
+		"no daylight saving time":   {timezone: types.StringNull(), start: time.Date(2025, 4, 7, 4, 0, 0, 0, time.UTC), wantDayOfWeek: "MONDAY", wantStartTime: "04:00"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := &clusterResourceModel{MaintenanceWindow: &maintenanceWindowModel{
+				DayOfWeek: types.StringValue("MONDAY"),
+				StartTime: types.StringValue("03:00"),
+				Duration:  types.StringValue("4h"),
+				Timezone:  test.timezone,
+			}}
+			saveMaintenanceFromResponse(state, &model.MdsCluster{
+				MaintenanceStartTime: test.start.UnixMilli(),
+				MaintenanceEndTime:   test.start.Add(4 * time.Hour).UnixMilli(),
+			})
+
+			window := state.MaintenanceWindow
+			if window.DayOfWeek.ValueString() != test.wantDayOfWeek || window.StartTime.ValueString() != test.wantStartTime {
+				t.Errorf("expected %s %s, got %s %s", test.wantDayOfWeek, test.wantStartTime, window.DayOfWeek, window.StartTime)
+			}
+			if window.Duration.ValueString() != "4h" {
+				t.Errorf("expected a duration of 4h, got %s", window.Duration)
+			}
+		})
+	}
+}
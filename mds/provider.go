@@ -7,11 +7,15 @@ import (
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -29,6 +33,9 @@ func New() provider.Provider {
 	return &mdsProvider{}
 }
 
+// userAgent identifies the provider in the requests sent to MDS.
+const userAgent = "terraform-provider-vmds"
+
 // mdsProvider is the provider implementation.
 type mdsProvider struct{}
 
@@ -42,6 +49,20 @@ type mdsProviderModel struct {
 	OrgId        types.String `tfsdk:"org_id"`
 	Username     types.String `tfsdk:"username"`
 	Password     types.String `tfsdk:"password"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64  `tfsdk:"retry_wait_max"`
+	CACert       types.String `tfsdk:"ca_cert"`
+	ClientCert   types.String `tfsdk:"client_cert"`
+	ClientKey    types.String `tfsdk:"client_key"`
+	Insecure     types.Bool   `tfsdk:"insecure"`
+
+	ProxyUrl            types.String `tfsdk:"proxy_url"`
+	MaxIdleConns        types.Int64  `tfsdk:"max_idle_conns"`
+	DialTimeout         types.Int64  `tfsdk:"dial_timeout"`
+	TLSHandshakeTimeout types.Int64  `tfsdk:"tls_handshake_timeout"`
+	RequestTimeout      types.Int64  `tfsdk:"request_timeout"`
+	OperationTimeout    types.Int64  `tfsdk:"operation_timeout"`
 }
 
 // Metadata returns the provider type name.
@@ -89,6 +110,60 @@ func (p *mdsProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries of an MDS API call failing with a transient error (`429`, `5xx`, connection reset). "+
+					"Calls which create resources are only retried when MDS surely did not process them. `0` disables retries. Default is `%d`.", core.DefaultMaxRetries),
+				Optional: true,
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Minimum time in seconds to wait before retrying, doubled (with jitter) on each attempt. Default is `%d`.", int64(core.DefaultRetryWaitMin.Seconds())),
+				Optional:            true,
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time in seconds to wait between two retries, a longer `Retry-After` asked by MDS is cut to it. Default is `%d`.", int64(core.DefaultRetryWaitMax.Seconds())),
+				Optional:            true,
+			},
+			"ca_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle (or path to it) trusted in addition to the system authorities, for MDS deployments using a private CA. May also be provided via *MDS_CA_CERT* environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate (or path to it), for MDS deployments requiring mutual TLS. Requires `client_key`. May also be provided via *MDS_CLIENT_CERT* environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key (or path to it) of `client_cert`. May also be provided via *MDS_CLIENT_KEY* environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure": schema.BoolAttribute{
+				MarkdownDescription: "Skips verification of the MDS API certificate. Meant for test environments only, never use it in production. May also be provided via *MDS_INSECURE* environment variable. Default is `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to reach MDS API through. May also be provided via *MDS_PROXY_URL* environment variable, otherwise the standard *HTTPS_PROXY*, *HTTP_PROXY* and *NO_PROXY* environment variables are honoured.",
+				Optional:            true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of idle (keep-alive) connections kept open to MDS API. Default is `%d`.", mds.DefaultMaxIdleConns),
+				Optional:            true,
+			},
+			"dial_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Time in seconds to wait for a connection to MDS API (or the proxy) to be established. Default is `%d`.", int64(mds.DefaultDialTimeout.Seconds())),
+				Optional:            true,
+			},
+			"tls_handshake_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Time in seconds to wait for the TLS handshake with MDS API. Default is `%d`.", int64(mds.DefaultTLSHandshakeTimeout.Seconds())),
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Time in seconds a single attempt of an MDS API call may take, a timed out attempt is retried as per `max_retries`. Default is `%d`.", int64(mds.DefaultRequestTimeout.Seconds())),
+				Optional:            true,
+			},
+			"operation_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Time in seconds a whole MDS API call may take, including retries. Default is `%d`.", int64(mds.DefaultOperationTimeout.Seconds())),
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -233,6 +308,10 @@ func (p *mdsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		}
 	}
 
+	retryPolicy := prepareRetryPolicy(&config, &resp.Diagnostics)
+	tlsConfig := prepareTLSConfig(&config, &resp.Diagnostics)
+	transportConfig := prepareTransportConfig(&config, &resp.Diagnostics)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -255,7 +334,7 @@ func (p *mdsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	tflog.Debug(ctx, "Creating MDS client")
 
 	// Create a new MDS client using the configuration values
-	client, err := mds.NewClient(&host, &model.ClientAuth{
+	client, err := mds.NewClientWithOptions(&host, &model.ClientAuth{
 		ApiToken:     apiToken,
 		ClientSecret: clientSecret,
 		ClientId:     clientId,
@@ -263,7 +342,15 @@ func (p *mdsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		OAuthAppType: config.Type.ValueString(),
 		Username:     username,
 		Password:     password,
-	})
+	},
+		mds.WithRetryPolicy(retryPolicy),
+		mds.WithTLSConfig(tlsConfig),
+		mds.WithTransportConfig(transportConfig),
+		mds.WithUserAgent(userAgent),
+		mds.WithLogger(core.LoggerFunc(func(ctx context.Context, msg string, fields map[string]any) {
+			tflog.Debug(ctx, msg, fields)
+		})),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create MDS API Client",
@@ -282,6 +369,112 @@ func (p *mdsProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	tflog.Info(ctx, "Configured MDS client", map[string]any{"success": true})
 }
 
+func prepareRetryPolicy(config *mdsProviderModel, diagnostics *diag.Diagnostics) *core.RetryPolicy {
+	retryPolicy := core.DefaultRetryPolicy()
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid MDS API Max Retries",
+				"The value of max_retries cannot be negative, set it to 0 to disable retries.",
+			)
+			return nil
+		}
+		retryPolicy.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryWaitMin.IsNull() {
+		retryPolicy.WaitMin = time.Duration(config.RetryWaitMin.ValueInt64()) * time.Second
+	}
+	if !config.RetryWaitMax.IsNull() {
+		retryPolicy.WaitMax = time.Duration(config.RetryWaitMax.ValueInt64()) * time.Second
+	}
+	if retryPolicy.WaitMin <= 0 || retryPolicy.WaitMax < retryPolicy.WaitMin {
+		diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid MDS API Retry Wait",
+			"The value of retry_wait_min must be positive and cannot exceed retry_wait_max.",
+		)
+	}
+	return retryPolicy
+}
+
+func prepareTLSConfig(config *mdsProviderModel, diagnostics *diag.Diagnostics) *mds.TLSConfig {
+	tlsConfig := &mds.TLSConfig{
+		CACert:     os.Getenv("MDS_CA_CERT"),
+		ClientCert: os.Getenv("MDS_CLIENT_CERT"),
+		ClientKey:  os.Getenv("MDS_CLIENT_KEY"),
+	}
+	if insecure, err := strconv.ParseBool(os.Getenv("MDS_INSECURE")); err == nil {
+		tlsConfig.Insecure = insecure
+	}
+
+	if !config.CACert.IsNull() {
+		tlsConfig.CACert = config.CACert.ValueString()
+	}
+	if !config.ClientCert.IsNull() {
+		tlsConfig.ClientCert = config.ClientCert.ValueString()
+	}
+	if !config.ClientKey.IsNull() {
+		tlsConfig.ClientKey = config.ClientKey.ValueString()
+	}
+	if !config.Insecure.IsNull() {
+		tlsConfig.Insecure = config.Insecure.ValueBool()
+	}
+
+	if (tlsConfig.ClientCert == "") != (tlsConfig.ClientKey == "") {
+		diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete MDS API Client Certificate",
+			"The values of client_cert and client_key must be provided together, "+
+				"either in the configuration or using the MDS_CLIENT_CERT and MDS_CLIENT_KEY environment variables.",
+		)
+	}
+	return tlsConfig
+}
+
+func prepareTransportConfig(config *mdsProviderModel, diagnostics *diag.Diagnostics) *mds.TransportConfig {
+	transportConfig := &mds.TransportConfig{
+		ProxyURL: os.Getenv("MDS_PROXY_URL"),
+	}
+	if !config.ProxyUrl.IsNull() {
+		transportConfig.ProxyURL = config.ProxyUrl.ValueString()
+	}
+	if !config.MaxIdleConns.IsNull() {
+		transportConfig.MaxIdleConns = int(config.MaxIdleConns.ValueInt64())
+	}
+
+	seconds := map[string]struct {
+		value  types.Int64
+		target *time.Duration
+	}{
+		"dial_timeout":          {config.DialTimeout, &transportConfig.DialTimeout},
+		"tls_handshake_timeout": {config.TLSHandshakeTimeout, &transportConfig.TLSHandshakeTimeout},
+		"request_timeout":       {config.RequestTimeout, &transportConfig.RequestTimeout},
+		"operation_timeout":     {config.OperationTimeout, &transportConfig.OperationTimeout},
+	}
+	for attribute, timeout := range seconds {
+		if timeout.value.IsNull() {
+			continue
+		}
+		if timeout.value.ValueInt64() <= 0 {
+			diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid MDS API Timeout",
+				fmt.Sprintf("The value of %s must be a positive number of seconds.", attribute),
+			)
+		}
+		*timeout.target = time.Duration(timeout.value.ValueInt64()) * time.Second
+	}
+	if !config.MaxIdleConns.IsNull() && config.MaxIdleConns.ValueInt64() <= 0 {
+		diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Invalid MDS API Max Idle Connections",
+			"The value of max_idle_conns must be positive.",
+		)
+	}
+	return transportConfig
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *mdsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
@@ -302,6 +495,9 @@ func (p *mdsProvider) DataSources(_ context.Context) []func() datasource.DataSou
 		NewCloudProviderRegionsDataSource,
 		NewTshirtSizeDatasource,
 		NewCertificatesDatasource,
+		NewClusterBackupsDatasource,
+		NewServiceVersionsDatasource,
+		NewClusterUpgradeTargetsDatasource,
 	}
 }
 
@@ -317,6 +513,9 @@ func (p *mdsProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewByocDataPlaneResourceResource,
 		NewCloudAccountResource,
 		NewCertificateResource,
+		NewClusterBackupResource,
+		NewClusterBackupPolicyResource,
+		NewClusterFleetUpgradeResource,
 	}
 }
 
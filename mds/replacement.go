@@ -0,0 +1,43 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// immutableAttribute is an attribute MDS cannot change on an existing object, with the reason why.
+type immutableAttribute struct {
+	Name   string
+	Reason string
+}
+
+// addReplacementWarnings explains, for each of the immutable attributes changed by the plan, why Terraform
+// is going to replace the object rather than update it. The replacement itself is planned by RequiresReplace.
+func addReplacementWarnings(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+	objectName string, attributes []immutableAttribute) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	for _, attribute := range attributes {
+		var planValue, stateValue types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attribute.Name), &planValue)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute.Name), &stateValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if planValue.Equal(stateValue) {
+			continue
+		}
+		newValue := planValue.ValueString()
+		if planValue.IsUnknown() {
+			newValue = "(known after apply)"
+		}
+		resp.Diagnostics.AddAttributeWarning(path.Root(attribute.Name), "Replacing "+objectName,
+			fmt.Sprintf("Changing `%s` from [%s] to [%s] requires a new %s, as %s. The existing %s is destroyed and a new one is created.",
+				attribute.Name, stateValue.ValueString(), newValue, objectName, attribute.Reason, objectName),
+		)
+	}
+}
@@ -2,7 +2,10 @@ package mds
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -10,11 +13,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/resource_status"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	infra_connector "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/infra-connector"
+	upgrade_service "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/upgrade-service"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDataPlaneTimeout       = 90 * time.Minute
+	defaultDataPlaneDeleteTimeout = 60 * time.Minute
+	defaultDataPlaneReadTimeout   = 5 * time.Minute
+	dataPlaneMinPollInterval      = 15 * time.Second
+	dataPlaneMaxPollInterval      = 60 * time.Second
+	// a created dataplane may not be visible right away
+	dataPlaneNotFoundChecks = 3
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -22,28 +42,39 @@ var (
 	_ resource.Resource                = &byocDataPlaneResource{}
 	_ resource.ResourceWithConfigure   = &byocDataPlaneResource{}
 	_ resource.ResourceWithImportState = &byocDataPlaneResource{}
+	_ resource.ResourceWithModifyPlan  = &byocDataPlaneResource{}
 )
 
 func NewByocDataPlaneResourceResource() resource.Resource {
 	return &byocDataPlaneResource{}
 }
 
+// dataPlaneImmutableAttributes are the attributes which replace the dataplane when changed.
+var dataPlaneImmutableAttributes = []immutableAttribute{
+	{Name: "account_id", Reason: "a dataplane cannot move to another cloud account"},
+	{Name: "name", Reason: "MDS cannot rename a dataplane"},
+	{Name: "certificate_id", Reason: "the certificate of a dataplane cannot be swapped"},
+	{Name: "region", Reason: "a dataplane cannot move to another region"},
+	{Name: "nodepool_type", Reason: "the node pool of a dataplane cannot be resized"},
+}
+
 type byocDataPlaneResource struct {
 	client *mds.Client
 }
 
 type byocDataPlaneResourceModel struct {
-	ID                   types.String `tfsdk:"id"`
-	Name                 types.String `tfsdk:"name"`
-	AccountId            types.String `tfsdk:"account_id"`
-	CertificateId        types.String `tfsdk:"certificate_id"`
-	NodePoolType         types.String `tfsdk:"nodepool_type"`
-	Region               types.String `tfsdk:"region"`
-	Status               types.String `tfsdk:"status"`
-	Version              types.String `tfsdk:"version"`
-	Provider             types.String `tfsdk:"provider_name"`
-	Certificate          types.Object `tfsdk:"certificate"`
-	DataPlaneReleaseName types.String `tfsdk:"data_plane_release_name"`
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	AccountId            types.String   `tfsdk:"account_id"`
+	CertificateId        types.String   `tfsdk:"certificate_id"`
+	NodePoolType         types.String   `tfsdk:"nodepool_type"`
+	Region               types.String   `tfsdk:"region"`
+	Status               types.String   `tfsdk:"status"`
+	Version              types.String   `tfsdk:"version"`
+	Provider             types.String   `tfsdk:"provider_name"`
+	Certificate          types.Object   `tfsdk:"certificate"`
+	DataPlaneReleaseName types.String   `tfsdk:"data_plane_release_name"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 type CertificateModel struct {
@@ -77,7 +108,7 @@ func (r *byocDataPlaneResource) Configure(_ context.Context, req resource.Config
 func (r *byocDataPlaneResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	tflog.Info(ctx, "INIT__Schema")
 	resp.Schema = schema.Schema{
-		Description: "Represents a Dataplane on BYOC. Supported actions are Add and Delete ",
+		Description: "Represents a Dataplane on BYOC. Supported actions are Add, Upgrade and Delete, changing `name`, `account_id`, `certificate_id`, `region` or `nodepool_type` replaces the dataplane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Auto-generated ID of the dataplane after creation, and can be used to import it from MDS to terraform state.",
@@ -89,22 +120,37 @@ func (r *byocDataPlaneResource) Schema(ctx context.Context, _ resource.SchemaReq
 			"account_id": schema.StringAttribute{
 				MarkdownDescription: "Id of the selected Cloud Account",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "Name of the DataPlane",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"certificate_id": schema.StringAttribute{
 				Description: "Id of the selected Certificate",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"nodepool_type": schema.StringAttribute{
 				Description: "Selected T-shirt Size; Values can be 'regular' or 'large'",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				Description: "Selected Region",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Description: "Status of the dataplane",
@@ -115,8 +161,16 @@ func (r *byocDataPlaneResource) Schema(ctx context.Context, _ resource.SchemaReq
 				Computed:    true,
 			},
 			"version": schema.StringAttribute{
-				Description: "K8S version",
-				Computed:    true,
+				MarkdownDescription: "K8S version. Changing it upgrades the dataplane, to one of the versions MDS offers to upgrade it to. " +
+					"MDS picks the version of a new dataplane when not set, a set one must be a version other dataplanes run or are offered.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(serviceVersionPattern, "must be a semantic version, e.g. 1.27.3"),
+				},
 			},
 			"data_plane_release_name": schema.StringAttribute{
 				Description: "Helm Release Name",
@@ -142,6 +196,12 @@ func (r *byocDataPlaneResource) Schema(ctx context.Context, _ resource.SchemaReq
 					},
 				},
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 
@@ -159,6 +219,13 @@ func (r *byocDataPlaneResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultDataPlaneTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	dataplaneRequest := infra_connector.DataPlaneCreateRequest{
 		Name:          plan.Name.ValueString(),
@@ -169,33 +236,92 @@ func (r *byocDataPlaneResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	tflog.Debug(ctx, "Create dataplane DTO", map[string]interface{}{"dto": dataplaneRequest})
-	if _, err := r.client.InfraConnector.CreateDataPlane(&dataplaneRequest); err != nil {
-
-		resp.Diagnostics.AddError(
-			"Submitting request to create dataplane",
-			"Could not create dataplane, unexpected error: "+err.Error(),
-		)
+	taskResponse, err := r.client.InfraConnector.CreateDataPlane(ctx, &dataplaneRequest)
+	if err != nil {
+		addApiError(&resp.Diagnostics, "Submitting request to create dataplane",
+			"Could not create dataplane, unexpected error: ", err, map[string]path.Path{
+				"name":          path.Root("name"),
+				"accountId":     path.Root("account_id"),
+				"certificateId": path.Root("certificate_id"),
+				"nodePoolType":  path.Root("nodepool_type"),
+				"region":        path.Root("region"),
+			})
 		return
 	}
 
-	dataplanes, err := r.client.InfraConnector.GetDataPlanes(&infra_connector.DataPlaneQuery{
-		Name: dataplaneRequest.Name,
-	})
+	tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+	task, err := r.client.TaskService.WaitForTask(ctx, taskResponse.TaskId)
 	if err != nil {
-		resp.Diagnostics.AddError("Fetching DataPlane",
-			"Could not fetch data plane, unexpected error: "+err.Error(),
+		// the dataplane may exist even though its task did not complete, keep track of it
+		dataplaneId := ""
+		if task != nil {
+			dataplaneId = task.ResourceId
+		}
+		if dataplaneId == "" {
+			dataplaneId, _ = r.findCreatedDataPlane(ctx, &dataplaneRequest)
+		}
+		if dataplaneId != "" {
+			saveIncompleteDataPlane(ctx, resp, &plan, &dataplaneRequest, dataplaneId, nil)
+		}
+		resp.Diagnostics.AddError("Creating dataplane",
+			fmt.Sprintf("Creation of dataplane [%s] did not complete: %s", dataplaneRequest.Name, err.Error()),
 		)
 		return
 	}
 
-	if len(*dataplanes.Get()) <= 0 {
+	dataplaneId := task.ResourceId
+	if dataplaneId == "" {
+		dataplaneId, err = r.findCreatedDataPlane(ctx, &dataplaneRequest)
+		if err != nil {
+			resp.Diagnostics.AddError("Fetching DataPlane",
+				"Could not fetch data plane, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if dataplaneId == "" {
+			resp.Diagnostics.AddError("Fetching dataplane",
+				"Unable to fetch the created dataplane",
+			)
+			return
+		}
+	}
+
+	waiter := core.StateChangeConf[model.DataPlane]{
+		Target:          []string{resource_status.READY},
+		Refresh:         dataPlaneStateRefreshFunc(r.client, dataplaneId),
+		MinPollInterval: dataPlaneMinPollInterval,
+		MaxPollInterval: dataPlaneMaxPollInterval,
+		NotFoundChecks:  dataPlaneNotFoundChecks,
+	}
+	createdDataPlane, err := waiter.WaitForState(ctx)
+	if err != nil {
+		saveIncompleteDataPlane(ctx, resp, &plan, &dataplaneRequest, dataplaneId, createdDataPlane)
 		resp.Diagnostics.AddError("Fetching dataplane",
-			"Unable to fetch the created dataplane",
+			fmt.Sprintf("Dataplane [%s] did not become ready: %s", dataplaneId, err.Error()),
 		)
 		return
 	}
-	createdDataPlane := &(*dataplanes.Get())[0]
 	tflog.Debug(ctx, "Created dataplane DTO", map[string]interface{}{"dto": createdDataPlane})
+
+	// MDS creates the dataplane at its version, a configured other one is upgraded to once it's ready
+	if !plan.Version.IsUnknown() && !sameVersion(plan.Version.ValueString(), createdDataPlane.K8SVersion) {
+		createdVersion, targetVersion := createdDataPlane.K8SVersion, plan.Version.ValueString()
+		var upgradedDataPlane *model.DataPlane
+		if err = validateUpgrade(createdVersion, targetVersion); err == nil {
+			upgradedDataPlane, err = upgradeDataPlane(ctx, r.client, dataplaneId, targetVersion)
+		}
+		if err != nil {
+			// keep the created dataplane in state at the version it runs, the next apply upgrades it again
+			if saveFromDataPlaneResponse(&ctx, &resp.Diagnostics, &plan, createdDataPlane) == 0 {
+				resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+			}
+			addApiError(&resp.Diagnostics, "Upgrading dataplane",
+				fmt.Sprintf("Dataplane [%s] was created at version %s, but could not be upgraded to %s: ", dataplaneId, createdVersion, targetVersion),
+				err, map[string]path.Path{"targetVersion": path.Root("version")})
+			return
+		}
+		createdDataPlane = upgradedDataPlane
+	}
 	if saveFromDataPlaneResponse(&ctx, &resp.Diagnostics, &plan, createdDataPlane) != 0 {
 		return
 	}
@@ -211,7 +337,55 @@ func (r *byocDataPlaneResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *byocDataPlaneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	return
+	tflog.Info(ctx, "INIT__Update")
+
+	var plan, state byocDataPlaneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Version.IsUnknown() && !sameVersion(plan.Version.ValueString(), state.Version.ValueString()) {
+		updateTimeout, diags := plan.Timeouts.Update(ctx, defaultDataPlaneTimeout)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+		defer cancel()
+
+		tflog.Info(ctx, "Version change detected", map[string]interface{}{
+			"old_version": state.Version.ValueString(),
+			"new_version": plan.Version.ValueString(),
+		})
+		dataplane, err := upgradeDataPlane(ctx, r.client, state.ID.ValueString(), plan.Version.ValueString())
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				resp.Diagnostics.AddAttributeError(path.Root("version"), "Upgrading Byoc DataPlane",
+					fmt.Sprintf("Upgrade of dataplane [%s] to version %s did not complete within %s, it may still be in progress in MDS. "+
+						"Refresh the dataplane once it completed, or raise the update timeout: %s",
+						state.ID.ValueString(), plan.Version.ValueString(), updateTimeout, err.Error()),
+				)
+				return
+			}
+			addApiError(&resp.Diagnostics, "Upgrading Byoc DataPlane",
+				fmt.Sprintf("Could not upgrade dataplane from version %s to %s: ", state.Version.ValueString(), plan.Version.ValueString()),
+				err, map[string]path.Path{"targetVersion": path.Root("version")})
+			return
+		}
+		// the configured version is kept when it designates the one upgraded to, e.g. 1.29 for 1.29.4
+		state.Version = plan.Version
+		if saveFromDataPlaneResponse(&ctx, &resp.Diagnostics, &state, dataplane) != 0 {
+			return
+		}
+		tflog.Info(ctx, "Dataplane version updated successfully")
+	}
+
+	// besides the version, only the timeouts can change in place
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	tflog.Info(ctx, "END__Update")
 }
 
 func (r *byocDataPlaneResource) Delete(ctx context.Context, request resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -224,8 +398,19 @@ func (r *byocDataPlaneResource) Delete(ctx context.Context, request resource.Del
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDataPlaneDeleteTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Submit request to delete Byoc DataPlane
-	err := r.client.InfraConnector.DeleteDataPlane(state.ID.ValueString())
+	err := r.client.InfraConnector.DeleteDataPlane(ctx, state.ID.ValueString())
+	if core.IsNotFound(err) {
+		tflog.Info(ctx, "END__Delete dataplane already removed")
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Deleting Byoc DataPlane",
@@ -234,9 +419,115 @@ func (r *byocDataPlaneResource) Delete(ctx context.Context, request resource.Del
 		return
 	}
 
+	waiter := core.StateChangeConf[model.DataPlane]{
+		Refresh:         dataPlaneStateRefreshFunc(r.client, state.ID.ValueString()),
+		MinPollInterval: dataPlaneMinPollInterval,
+		MaxPollInterval: dataPlaneMaxPollInterval,
+	}
+	if _, err = waiter.WaitForState(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Deleting Byoc DataPlane",
+			fmt.Sprintf("Dataplane [%s] was not removed: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	tflog.Info(ctx, "END__Delete")
 }
 
+// ModifyPlan explains a planned replacement of the dataplane, and validates the version of a new dataplane or
+// an upgrade against the allowed targets
+func (r *byocDataPlaneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	addReplacementWarnings(ctx, req, resp, "dataplane", dataPlaneImmutableAttributes)
+	if req.State.Raw.IsNull() {
+		// nothing can be fetched before the provider is configured
+		if r.client != nil {
+			r.validateVersion(ctx, req, resp)
+		}
+		return
+	}
+	validateVersionChange(ctx, req, resp)
+	// nothing can be fetched before the provider is configured
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+	validateUpgradeTarget(ctx, req, resp, "Dataplane", r.client.UpgradeService.GetDataPlaneUpgradeTargets)
+}
+
+// validateVersion checks the version of a new dataplane against the versions the dataplanes of MDS run or are offered
+// to upgrade to, MDS lists no versions a dataplane can be created with. The first dataplane is left to MDS to validate.
+func (r *byocDataPlaneResource) validateVersion(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var version types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("version"), &version)...)
+	if resp.Diagnostics.HasError() || version.IsUnknown() || version.IsNull() {
+		return
+	}
+
+	dataplanes, err := r.client.InfraConnector.GetAllDataPlanes(ctx, &infra_connector.DataPlaneQuery{}, nil)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Validating version",
+			"Could not fetch dataplanes, the version is left to MDS to validate: "+err.Error(),
+		)
+		return
+	}
+	var versions []string
+	offered := map[string]bool{}
+	offer := func(version string) {
+		if version != "" && !offered[version] {
+			offered[version] = true
+			versions = append(versions, version)
+		}
+	}
+	designated := func(candidates []string) bool {
+		for _, candidate := range candidates {
+			if sameVersion(version.ValueString(), candidate) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, dataplane := range dataplanes {
+		offer(dataplane.K8SVersion)
+	}
+	if designated(versions) {
+		return
+	}
+	// the upgrade targets depend on the version a dataplane runs, they're fetched once per version until one matches
+	fetched := map[string]bool{}
+	for _, dataplane := range dataplanes {
+		if fetched[dataplane.K8SVersion] {
+			continue
+		}
+		fetched[dataplane.K8SVersion] = true
+		upgradeTargets, err := r.client.UpgradeService.GetDataPlaneUpgradeTargets(ctx, dataplane.Id)
+		if err != nil {
+			resp.Diagnostics.AddWarning("Validating version",
+				"Could not fetch upgrade targets, the version is left to MDS to validate: "+err.Error(),
+			)
+			return
+		}
+		if designated(upgradeTargets.TargetVersions) {
+			return
+		}
+		for _, targetVersion := range upgradeTargets.TargetVersions {
+			offer(targetVersion)
+		}
+	}
+	if len(versions) == 0 {
+		return
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid version",
+		fmt.Sprintf("Dataplane cannot be created at version %s. Supported values: %s", version.ValueString(), strings.Join(versions, ", ")),
+	)
+}
+
 func (r *byocDataPlaneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -251,8 +542,18 @@ func (r *byocDataPlaneResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultDataPlaneReadTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed dataplane value
-	dataplane, err := r.client.InfraConnector.GetDataPlaneById(state.ID.ValueString())
+	dataplane, err := r.client.InfraConnector.GetDataPlaneById(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading Byoc Dataplane",
@@ -276,17 +577,111 @@ func (r *byocDataPlaneResource) Read(ctx context.Context, req resource.ReadReque
 	tflog.Info(ctx, "END__Read")
 }
 
+// dataPlaneStateRefreshFunc refreshes the dataplane for a waiter, a dataplane which failed ends the wait with an error.
+func dataPlaneStateRefreshFunc(client *mds.Client, id string) core.StateRefreshFunc[model.DataPlane] {
+	return func(ctx context.Context) (*model.DataPlane, string, error) {
+		dataplane, err := client.InfraConnector.GetDataPlaneById(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if dataplane.Status == resource_status.FAILED {
+			return &dataplane, dataplane.Status, fmt.Errorf("dataplane [%s] is in status %s", id, dataplane.Status)
+		}
+		return &dataplane, dataplane.Status, nil
+	}
+}
+
+// upgradeDataPlane upgrades the dataplane to the target version, and waits for MDS to report it ready at that version.
+// A target given by its leading numbers is resolved to the version MDS offers.
+func upgradeDataPlane(ctx context.Context, client *mds.Client, id string, targetVersion string) (*model.DataPlane, error) {
+	targetVersion = resolveUpgradeTarget(ctx, id, targetVersion, client.UpgradeService.GetDataPlaneUpgradeTargets)
+	versionUpdateRequest := upgrade_service.UpdateDataPlaneVersionRequest{
+		TargetVersion: targetVersion,
+	}
+	tflog.Debug(ctx, "Upgrading dataplane", map[string]interface{}{"id": id, "target_version": targetVersion})
+	if _, err := client.UpgradeService.UpdateDataPlaneVersion(ctx, id, &versionUpdateRequest); err != nil {
+		return nil, err
+	}
+
+	// the dataplane leaves the ready status only some time after the upgrade was submitted
+	refresh := dataPlaneStateRefreshFunc(client, id)
+	started := false
+	waiter := core.StateChangeConf[model.DataPlane]{
+		Target: []string{upgradeDone},
+		Refresh: func(ctx context.Context) (*model.DataPlane, string, error) {
+			dataplane, _, err := refresh(ctx)
+			if err != nil || dataplane == nil {
+				return dataplane, "", err
+			}
+			switch {
+			case dataplane.Status != resource_status.READY:
+				started = true
+				return dataplane, upgradeInProgress, nil
+			case sameVersion(targetVersion, dataplane.K8SVersion):
+				return dataplane, upgradeDone, nil
+			case started:
+				return dataplane, "", fmt.Errorf("upgrade ended with the dataplane at version %s", dataplane.K8SVersion)
+			}
+			return dataplane, upgradePending, nil
+		},
+		MinPollInterval: dataPlaneMinPollInterval,
+		MaxPollInterval: dataPlaneMaxPollInterval,
+		OnRefresh: func(dataplane *model.DataPlane, state string) {
+			tflog.Info(ctx, "Upgrading dataplane", map[string]interface{}{
+				"id": id, "state": state, "status": dataplane.Status, "version": dataplane.K8SVersion,
+			})
+		},
+	}
+	return waiter.WaitForState(ctx)
+}
+
+// findCreatedDataPlane returns the ID of the dataplane created by the request, empty when there is none by its name.
+func (r *byocDataPlaneResource) findCreatedDataPlane(ctx context.Context, dataplaneRequest *infra_connector.DataPlaneCreateRequest) (string, error) {
+	dataplanes, err := r.client.InfraConnector.GetAllDataPlanes(ctx, &infra_connector.DataPlaneQuery{
+		Name: dataplaneRequest.Name,
+	}, func(dataplane *model.DataPlane) bool {
+		return dataplane.Name == dataplaneRequest.Name
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(dataplanes) <= 0 || dataplanes[len(dataplanes)-1].Name != dataplaneRequest.Name {
+		return "", nil
+	}
+	return dataplanes[len(dataplanes)-1].Id, nil
+}
+
+// saveIncompleteDataPlane keeps track of a dataplane whose creation did not complete, Terraform marks it tainted and
+// replaces it on the next apply. The dataplane is saved as requested when MDS did not return it.
+func saveIncompleteDataPlane(ctx context.Context, resp *resource.CreateResponse, plan *byocDataPlaneResourceModel,
+	dataplaneRequest *infra_connector.DataPlaneCreateRequest, dataplaneId string, dataplane *model.DataPlane) {
+	if dataplane == nil {
+		dataplane = &model.DataPlane{
+			Id:         dataplaneId,
+			Name:       dataplaneRequest.Name,
+			Region:     dataplaneRequest.Region,
+			TshirtSize: dataplaneRequest.TshirtSize,
+			K8SVersion: plan.Version.ValueString(),
+		}
+	}
+	if saveFromDataPlaneResponse(&ctx, &resp.Diagnostics, plan, dataplane) == 0 {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	}
+}
+
 func saveFromDataPlaneResponse(ctx *context.Context, diagnostics *diag.Diagnostics, state *byocDataPlaneResourceModel, byocDataPlane *model.DataPlane) int8 {
 	tflog.Info(*ctx, "Saving response to resourceModel state/plan", map[string]interface{}{"byocDataPlane": *byocDataPlane})
 
 	state.ID = types.StringValue(byocDataPlane.Id)
 	state.Name = types.StringValue(byocDataPlane.Name)
 	state.Status = types.StringValue(byocDataPlane.Status)
-	state.Version = types.StringValue(byocDataPlane.K8SVersion)
+	// a version given by its leading numbers, e.g. 1.27 for 1.27.3, is kept as configured
+	if state.Version.IsUnknown() || state.Version.IsNull() || !sameVersion(state.Version.ValueString(), byocDataPlane.K8SVersion) {
+		state.Version = types.StringValue(byocDataPlane.K8SVersion)
+	}
 	state.Provider = types.StringValue(byocDataPlane.Provider)
 	state.DataPlaneReleaseName = types.StringValue(byocDataPlane.DataPlaneReleaseName)
 	state.Status = types.StringValue(byocDataPlane.Status)
-	state.Version = types.StringValue(byocDataPlane.K8SVersion)
 	state.NodePoolType = types.StringValue(byocDataPlane.TshirtSize)
 
 	certificateResponseModel := CertificateModel{
@@ -2,8 +2,8 @@ package mds
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,8 +16,11 @@ import (
 	infra_connector "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/infra-connector"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"net/url"
+	"time"
 )
 
+const defaultCertificateTimeout = 5 * time.Minute
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &certificateResource{}
@@ -34,15 +37,16 @@ type certificateResource struct {
 }
 
 type CertificateResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	DomainName     types.String `tfsdk:"domain_name"`
-	ProviderType   types.String `tfsdk:"provider_type"`
-	ExpirationTime types.String `tfsdk:"expiration_time"`
-	CreatedBy      types.String `tfsdk:"created_by"`
-	Certificate    types.String `tfsdk:"certificate"`
-	CertificateCA  types.String `tfsdk:"certificate_ca"`
-	CertificateKey types.String `tfsdk:"certificate_key"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	DomainName     types.String   `tfsdk:"domain_name"`
+	ProviderType   types.String   `tfsdk:"provider_type"`
+	ExpirationTime types.String   `tfsdk:"expiration_time"`
+	CreatedBy      types.String   `tfsdk:"created_by"`
+	Certificate    types.String   `tfsdk:"certificate"`
+	CertificateCA  types.String   `tfsdk:"certificate_ca"`
+	CertificateKey types.String   `tfsdk:"certificate_key"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *certificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -113,6 +117,12 @@ func (r *certificateResource) Schema(ctx context.Context, _ resource.SchemaReque
 				MarkdownDescription: "Certificate Key details",
 				Required:            true,
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 
@@ -129,6 +139,13 @@ func (r *certificateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCertificateTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	certificateRequest := &infra_connector.CertificateCreateRequest{
 		Name:           plan.Name.ValueString(),
@@ -140,15 +157,17 @@ func (r *certificateResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	tflog.Info(ctx, "req param", map[string]interface{}{"reeed": certificateRequest})
-	certificate, err := r.client.InfraConnector.CreateCertificate(certificateRequest)
+	certificate, err := r.client.InfraConnector.CreateCertificate(ctx, certificateRequest)
 	if err != nil {
-		apiErr := core.ApiError{}
-		errors.As(err, &apiErr)
-		resp.Diagnostics.AddError(
-			"Submitting request to create certificate",
-			"There was some issue while creating the certificate."+
-				" Unexpected error: "+err.Error(),
-		)
+		addApiError(&resp.Diagnostics, "Submitting request to create certificate",
+			"There was some issue while creating the certificate. Unexpected error: ", err, map[string]path.Path{
+				"name":           path.Root("name"),
+				"domainName":     path.Root("domain_name"),
+				"provider":       path.Root("provider_type"),
+				"certificate":    path.Root("certificate"),
+				"certificateCA":  path.Root("certificate_ca"),
+				"certificateKey": path.Root("certificate_key"),
+			})
 		return
 	}
 
@@ -178,6 +197,13 @@ func (r *certificateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := state.Timeouts.Update(ctx, defaultCertificateTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	certificateUpdateReq := infra_connector.CertificateUpdateRequest{
 		Certificate:    url.PathEscape(state.Certificate.ValueString()),
 		CertificateCA:  url.PathEscape(state.CertificateCA.ValueString()),
@@ -185,7 +211,7 @@ func (r *certificateResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Update existing svc account
-	if _, err := r.client.InfraConnector.UpdateCertificate(state.ID.ValueString(), &certificateUpdateReq); err != nil {
+	if _, err := r.client.InfraConnector.UpdateCertificate(ctx, state.ID.ValueString(), &certificateUpdateReq); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating the Certificate",
 			"Could not update certificate, unexpected error: "+err.Error(),
@@ -193,7 +219,7 @@ func (r *certificateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	certificate, err := r.client.InfraConnector.GetCertificate(state.ID.ValueString())
+	certificate, err := r.client.InfraConnector.GetCertificate(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching certificate",
 			"Could not fetch certificate while updating, unexpected error: "+err.Error(),
@@ -224,9 +250,16 @@ func (r *certificateResource) Delete(ctx context.Context, request resource.Delet
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultCertificateTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Submit request to delete MDS certificate
-	err := r.client.InfraConnector.DeleteCertificate(state.ID.ValueString())
-	if err != nil {
+	err := r.client.InfraConnector.DeleteCertificate(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting certificate",
 			"Could not delete certificate by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -251,8 +284,18 @@ func (r *certificateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultCertificateTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed certificate value from MDS
-	certificate, err := r.client.InfraConnector.GetCertificate(state.ID.ValueString())
+	certificate, err := r.client.InfraConnector.GetCertificate(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading MDS certificate",
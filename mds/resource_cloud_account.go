@@ -3,7 +3,6 @@ package mds
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -127,15 +126,14 @@ func (r *cloudAccountResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	tflog.Info(ctx, "req param", map[string]interface{}{"reeed": cloudAccountRequest})
-	cloudAccount, err := r.client.InfraConnector.CreateCloudAccount(cloudAccountRequest)
+	cloudAccount, err := r.client.InfraConnector.CreateCloudAccount(ctx, cloudAccountRequest)
 	if err != nil {
-		apiErr := core.ApiError{}
-		errors.As(err, &apiErr)
-		resp.Diagnostics.AddError(
-			"Submitting request to create cloud account",
-			"There was some issue while creating the cloud account."+
-				" Unexpected error: "+err.Error(),
-		)
+		addApiError(&resp.Diagnostics, "Submitting request to create cloud account",
+			"There was some issue while creating the cloud account. Unexpected error: ", err, map[string]path.Path{
+				"name":        path.Root("name"),
+				"type":        path.Root("provider_type"),
+				"credentials": path.Root("credential"),
+			})
 		return
 	}
 
@@ -177,7 +175,7 @@ func (r *cloudAccountResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Update existing cloud account
-	if err := r.client.InfraConnector.UpdateCloudAccount(state.ID.ValueString(), &cred); err != nil {
+	if err := r.client.InfraConnector.UpdateCloudAccount(ctx, state.ID.ValueString(), &cred); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating MDS cloud account",
 			"Could not update cloud account, unexpected error: "+err.Error(),
@@ -185,7 +183,7 @@ func (r *cloudAccountResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	cloudAccount, err := r.client.InfraConnector.GetCloudAccount(state.ID.ValueString())
+	cloudAccount, err := r.client.InfraConnector.GetCloudAccount(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching cloud account",
 			"Could not fetch cloud account while updating, unexpected error: "+err.Error(),
@@ -217,8 +215,8 @@ func (r *cloudAccountResource) Delete(ctx context.Context, request resource.Dele
 	}
 
 	// Submit request to delete MDS cloud Account
-	err := r.client.InfraConnector.DeleteCloudAccount(state.ID.ValueString())
-	if err != nil {
+	err := r.client.InfraConnector.DeleteCloudAccount(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting MDS cloud account",
 			"Could not delete MDS cloud account by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -244,7 +242,10 @@ func (r *cloudAccountResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	// Get refreshed cloud account value from MDS
-	cloudAcct, err := r.client.InfraConnector.GetCloudAccount(state.ID.ValueString())
+	cloudAcct, err := r.client.InfraConnector.GetCloudAccount(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading MDS cloud account",
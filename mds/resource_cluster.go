@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
@@ -16,21 +18,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/policy_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/resource_status"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/upgrade_request_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	customer_metadata "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 	upgrade_service "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/upgrade-service"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
-	"net/http"
+	"strings"
 	"time"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &clusterResource{}
-	_ resource.ResourceWithConfigure   = &clusterResource{}
-	_ resource.ResourceWithImportState = &clusterResource{}
+	_ resource.Resource                   = &clusterResource{}
+	_ resource.ResourceWithConfigure      = &clusterResource{}
+	_ resource.ResourceWithImportState    = &clusterResource{}
+	_ resource.ResourceWithModifyPlan     = &clusterResource{}
+	_ resource.ResourceWithValidateConfig = &clusterResource{}
+	_ resource.ResourceWithUpgradeState   = &clusterResource{}
 )
 
 func NewClusterResource() resource.Resource {
@@ -41,40 +50,93 @@ type clusterResource struct {
 	client *mds.Client
 }
 
+const (
+	defaultClusterTimeout     = 60 * time.Minute
+	defaultClusterReadTimeout = 5 * time.Minute
+	clusterMinPollInterval    = 10 * time.Second
+	clusterMaxPollInterval    = 60 * time.Second
+	// a created cluster may not be visible right away
+	clusterNotFoundChecks = 3
+)
+
+// states of a cluster while waiting for its upgrade
+const (
+	upgradePending    = "UPGRADE_PENDING"
+	upgradeInProgress = "UPGRADE_IN_PROGRESS"
+	upgradeDone       = "UPGRADED"
+)
+
+// defaultClusterTimeouts are the default create, update and delete timeouts by service type,
+// databases take longer to provision and upgrade than messaging or caching services.
+var defaultClusterTimeouts = map[string]time.Duration{
+	service_type.POSTGRES: 90 * time.Minute,
+	service_type.MYSQL:    90 * time.Minute,
+	service_type.RABBITMQ: 45 * time.Minute,
+	service_type.REDIS:    30 * time.Minute,
+}
+
+// clusterImmutableAttributes are the attributes which replace the cluster when changed.
+var clusterImmutableAttributes = []immutableAttribute{
+	{Name: "name", Reason: "MDS cannot rename a cluster"},
+	{Name: "service_type", Reason: "a cluster runs a single service for its whole life"},
+	{Name: "cloud_provider", Reason: "a cluster cannot move to another cloud provider"},
+	{Name: "region", Reason: "a cluster cannot move to another region"},
+	{Name: "data_plane_id", Reason: "a cluster cannot move to another data-plane"},
+	{Name: "storage_policy_name", Reason: "the volumes of a cluster cannot move to another storage policy"},
+}
+
+// clusterFieldPaths maps the fields of the create request to their attribute, to report validation errors.
+// Fields of the cluster metadata map to the `cluster_metadata` block of the service type.
+func clusterFieldPaths(serviceType string) map[string]path.Path {
+	fieldPaths := map[string]path.Path{
+		"name":              path.Root("name"),
+		"serviceType":       path.Root("service_type"),
+		"provider":          path.Root("cloud_provider"),
+		"instanceSize":      path.Root("instance_size"),
+		"region":            path.Root("region"),
+		"tags":              path.Root("tags"),
+		"networkPolicyIds":  path.Root("network_policy_ids"),
+		"dataPlaneId":       path.Root("data_plane_id"),
+		"version":           path.Root("version"),
+		"storagePolicyName": path.Root("storage_policy_name"),
+	}
+	if block, ok := clusterMetadataBlocks[serviceType]; ok {
+		blockSchema := clusterMetadataSchema().Attributes[block].(schema.SingleNestedAttribute)
+		for name := range blockSchema.Attributes {
+			fieldPaths["clusterMetadata."+name] = path.Root("cluster_metadata").AtName(block).AtName(name)
+		}
+	}
+	return fieldPaths
+}
+
 // clusterResourceModel maps the resource schema data.
 type clusterResourceModel struct {
-	ID                types.String          `tfsdk:"id"`
-	OrgId             types.String          `tfsdk:"org_id"`
-	Name              types.String          `tfsdk:"name"`
-	ServiceType       types.String          `tfsdk:"service_type"`
-	Provider          types.String          `tfsdk:"cloud_provider"`
-	InstanceSize      types.String          `tfsdk:"instance_size"`
-	Region            types.String          `tfsdk:"region"`
-	Tags              types.Set             `tfsdk:"tags"`
-	NetworkPolicyIds  types.Set             `tfsdk:"network_policy_ids"`
-	Dedicated         types.Bool            `tfsdk:"dedicated"`
-	Shared            types.Bool            `tfsdk:"shared"`
-	Status            types.String          `tfsdk:"status"`
-	DataPlaneId       types.String          `tfsdk:"data_plane_id"`
-	LastUpdated       types.String          `tfsdk:"last_updated"`
-	Created           types.String          `tfsdk:"created"`
-	Metadata          types.Object          `tfsdk:"metadata"`
-	Version           types.String          `tfsdk:"version"`
-	StoragePolicyName types.String          `tfsdk:"storage_policy_name"`
-	ClusterMetadata   *clusterMetadataModel `tfsdk:"cluster_metadata"`
-	Upgrade           *upgradeMetadata      `tfsdk:"upgrade"`
+	ID                types.String            `tfsdk:"id"`
+	OrgId             types.String            `tfsdk:"org_id"`
+	Name              types.String            `tfsdk:"name"`
+	ServiceType       types.String            `tfsdk:"service_type"`
+	Provider          types.String            `tfsdk:"cloud_provider"`
+	InstanceSize      types.String            `tfsdk:"instance_size"`
+	Region            types.String            `tfsdk:"region"`
+	Tags              types.Set               `tfsdk:"tags"`
+	NetworkPolicyIds  types.Set               `tfsdk:"network_policy_ids"`
+	Dedicated         types.Bool              `tfsdk:"dedicated"`
+	Shared            types.Bool              `tfsdk:"shared"`
+	Status            types.String            `tfsdk:"status"`
+	DataPlaneId       types.String            `tfsdk:"data_plane_id"`
+	LastUpdated       types.String            `tfsdk:"last_updated"`
+	Created           types.String            `tfsdk:"created"`
+	Metadata          types.Object            `tfsdk:"metadata"`
+	Version           types.String            `tfsdk:"version"`
+	StoragePolicyName types.String            `tfsdk:"storage_policy_name"`
+	ClusterMetadata   *clusterMetadataModel   `tfsdk:"cluster_metadata"`
+	Upgrade           *upgradeMetadata        `tfsdk:"upgrade"`
+	MaintenanceWindow *maintenanceWindowModel `tfsdk:"maintenance_window"`
+	PauseUpdates      types.Bool              `tfsdk:"pause_updates"`
+	Timeouts          timeouts.Value          `tfsdk:"timeouts"`
 	// TODO add upgrade related fields
 }
 
-// clusterMetadataModel maps order item data.
-type clusterMetadataModel struct {
-	Username    types.String `tfsdk:"username"`
-	Password    types.String `tfsdk:"password"`
-	Database    types.String `tfsdk:"database"`
-	RestoreFrom types.String `tfsdk:"restore_from"`
-	Extensions  types.Set    `tfsdk:"extensions"`
-}
-
 type MetadataModel struct {
 	ManagerUri       types.String `tfsdk:"manager_uri"`
 	ConnectionUri    types.String `tfsdk:"connection_uri"`
@@ -113,9 +175,12 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 	tflog.Info(ctx, "INIT__Schema")
 
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Represents a service instance or cluster. Some attributes are used only once for creation, they are: `dedicated`, `network_policy_ids`." +
-			"\nChanging only `tags` is supported at the moment. If you wish to update network policies associated with it, please refer resource: " +
-			"`vmds_cluster_network_policies_association`.",
+		// version 1 moved the Postgres settings of cluster_metadata into a block per service type
+		Version: 1,
+		MarkdownDescription: "Represents a service instance or cluster. Some attributes are used only once for creation, they are: `dedicated`, `shared`." +
+			"\nChanging only `tags`, `instance_size` and `network_policy_ids` is supported at the moment, changing `name`, `service_type`, `cloud_provider`, `region`, " +
+			"`data_plane_id` or `storage_policy_name` replaces the cluster. The network policies of a cluster are managed either by `network_policy_ids` " +
+			"or by the resource `vmds_cluster_network_policies_association`, not both.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "ID of the cluster.",
@@ -136,6 +201,7 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"service_type": schema.StringAttribute{
@@ -145,6 +211,7 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Default:             stringdefault.StaticString(service_type.RABBITMQ),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"cloud_provider": schema.StringAttribute{
@@ -153,11 +220,13 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"instance_size": schema.StringAttribute{
 				MarkdownDescription: "Size of instance. Supported values are: `XX-SMALL`, `X-SMALL`, `SMALL`, `LARGE`, `XX-LARGE`." +
-					"\nPlease make use of datasource `vmds_network_ports` to decide on a size based on resources it requires.",
+					"\nPlease make use of datasource `vmds_network_ports` to decide on a size based on resources it requires." +
+					"\nChanging it resizes the cluster in place, the size must be one of datasource `vmds_instance_types` for the service type.",
 				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -175,12 +244,18 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 			"dedicated": schema.BoolAttribute{
 				Description: "If present and set to `true`, the cluster will get deployed on a dedicated data-plane in current Org.",
 				Optional:    true,
-				Computed:    false,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"shared": schema.BoolAttribute{
 				Description: "If present and set to `true`, the cluster will get deployed on a shared data-plane in current Org.",
 				Optional:    true,
-				Computed:    false,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"tags": schema.SetAttribute{
 				Description: "Set of tags or labels to categorise the cluster.",
@@ -206,6 +281,7 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"last_updated": schema.StringAttribute{
@@ -220,9 +296,10 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"version": schema.StringAttribute{
-				Description: "Version of the Postgres cluster.",
-				Required:    true,
-				Optional:    true,
+				MarkdownDescription: "Version of the service of the cluster, e.g. `15.4`. Changing it upgrades the cluster in place, " +
+					"only to a newer version, see `upgrade` for its options. MDS picks the version when not set.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -232,6 +309,7 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"metadata": schema.SingleNestedAttribute{
@@ -271,49 +349,33 @@ func (r *clusterResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
-			"cluster_metadata": schema.SingleNestedAttribute{
-				Description: "Additional info for the cluster.",
-				Required:    true,
-				Attributes: map[string]schema.Attribute{
-					"username": schema.StringAttribute{
-						Description: "Username for the cluster.",
-						Required:    true,
-					},
-					"password": schema.StringAttribute{
-						Description: "Password for the cluster.",
-						Required:    true,
-					},
-					"database": schema.StringAttribute{
-						Description: "Database name in the cluster.",
-						Required:    false,
-						Optional:    true,
-					},
-					"restore_from": schema.StringAttribute{
-						Description: "Restore from a specific backup.",
-						Optional:    true,
-					},
-					"extensions": schema.SetAttribute{
-						Description: "Set of extensions to be enabled on the cluster.",
-						Optional:    true,
-						ElementType: types.StringType,
-					},
-				},
+			"cluster_metadata":   clusterMetadataSchema(),
+			"maintenance_window": maintenanceWindowSchema(),
+			"pause_updates": schema.BoolAttribute{
+				Description: "If present and set to `true`, MDS does not apply upgrades and patches to the cluster.",
+				Optional:    true,
 			},
 			"upgrade": schema.SingleNestedAttribute{
-				Description: "To create the backup or not while upgrading",
-				Required:    false,
-				Optional:    true,
+				MarkdownDescription: "Options of the upgrade applied when `version` changes.",
+				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"target_version": schema.StringAttribute{
-						Description: "To Upgrade version",
-						Optional:    true,
+						Description:        "Version to upgrade to.",
+						Optional:           true,
+						DeprecationMessage: "Upgrades are driven by `version`, set it to the version to upgrade to instead.",
 					},
 					"omit_backup": schema.BoolAttribute{
-						Description: "set to take backup before upgrade",
+						Description: "If present and set to `true`, no backup of the cluster is taken before it's upgraded.",
 						Optional:    true,
 					},
 				},
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 
@@ -333,6 +395,13 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
 		return
 	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, clusterTimeout(plan.ServiceType.ValueString()))
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 	tflog.Info(ctx, "INIT__Creating req body")
 
 	// Generate API request body from plan
@@ -347,14 +416,9 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 		DataPlaneId:       plan.DataPlaneId.ValueString(),
 		Version:           plan.Version.ValueString(),
 		StoragePolicyName: plan.StoragePolicyName.ValueString(),
-		ClusterMetadata: controller.PostgresClusterMetadata{
-			Username: plan.ClusterMetadata.Username.ValueString(),
-			Password: plan.ClusterMetadata.Password.ValueString(),
-			Database: plan.ClusterMetadata.Database.ValueString(),
-		},
+		ClusterMetadata:   plan.ClusterMetadata.request(ctx, plan.ServiceType.ValueString()),
 	}
 
-	plan.ClusterMetadata.Extensions.ElementsAs(ctx, &clusterRequest.ClusterMetadata.Extensions, true)
 	tflog.Info(ctx, "INIT__Created req body")
 	tflog.Info(ctx, "Creating cluster", map[string]interface{}{
 		"cluster_request": clusterRequest,
@@ -365,50 +429,98 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 
 	tflog.Info(ctx, "INIT__Submitting request")
 
-	if _, err := r.client.Controller.CreateMdsCluster(&clusterRequest); err != nil {
-		resp.Diagnostics.AddError(
-			"Submitting request to create cluster",
-			"Could not create cluster, unexpected error: "+err.Error(),
-		)
+	taskResponse, err := r.client.Controller.CreateMdsCluster(ctx, &clusterRequest)
+	if err != nil {
+		addApiError(&resp.Diagnostics, "Submitting request to create cluster",
+			"Could not create cluster, unexpected error: ", err, clusterFieldPaths(clusterRequest.ServiceType))
 		return
 	}
-	tflog.Info(ctx, "INIT__Fetching clusters")
-	clusters, err := r.client.Controller.GetMdsClusters(&controller.MdsClustersQuery{
-		ServiceType:   clusterRequest.ServiceType,
-		Name:          clusterRequest.Name,
-		FullNameMatch: true,
-	})
+
+	tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+	var onProgress func(task *model.MdsTask)
+	restoreFrom := plan.ClusterMetadata.restoreFrom(clusterRequest.ServiceType)
+	if restoreFrom != "" {
+		onProgress = func(task *model.MdsTask) {
+			tflog.Info(ctx, "Restoring cluster from backup", map[string]interface{}{
+				"backup_id": restoreFrom,
+				"status":    task.Status,
+				"progress":  task.Progress,
+			})
+		}
+	}
+	task, err := r.client.TaskService.WaitForTaskProgress(ctx, taskResponse.TaskId, onProgress)
 	if err != nil {
-		resp.Diagnostics.AddError("Fetching clusters",
-			"Could not fetch clusters by name, unexpected error: "+err.Error(),
+		// the cluster may exist even though its task did not complete, keep track of it
+		clusterId := ""
+		if task != nil {
+			clusterId = task.ResourceId
+		}
+		if clusterId == "" {
+			clusterId, _ = r.findCreatedCluster(ctx, &clusterRequest)
+		}
+		if clusterId != "" {
+			saveIncompleteCluster(ctx, resp, &plan, &clusterRequest, clusterId, nil)
+		}
+		if restoreFrom != "" {
+			resp.Diagnostics.AddError("Restoring cluster",
+				fmt.Sprintf("Restore of cluster [%s] from backup [%s] did not complete: %s", clusterRequest.Name, restoreFrom, err.Error()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Creating cluster",
+			fmt.Sprintf("Creation of cluster [%s] did not complete: %s", clusterRequest.Name, err.Error()),
 		)
 		return
 	}
 
-	if len(*clusters.Get()) <= 0 {
-		resp.Diagnostics.AddError("Fetching Clusters",
-			"Unable to fetch the created cluster",
-		)
-		return
+	clusterId := task.ResourceId
+	if clusterId == "" {
+		tflog.Info(ctx, "INIT__Fetching clusters")
+		clusterId, err = r.findCreatedCluster(ctx, &clusterRequest)
+		if err != nil {
+			resp.Diagnostics.AddError("Fetching clusters",
+				"Could not fetch clusters by name, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if clusterId == "" {
+			resp.Diagnostics.AddError("Fetching Clusters",
+				"Unable to fetch the created cluster",
+			)
+			return
+		}
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	createdCluster := &(*clusters.Get())[0]
-	if createdCluster.Status == "FAILED" {
-		resp.Diagnostics.AddError("Error creating cluster",
-			"Cluster creation failed with the status 'FAILED'")
+	waiter := core.StateChangeConf[model.MdsCluster]{
+		Target:          []string{resource_status.READY},
+		Refresh:         clusterStateRefreshFunc(r.client, clusterId),
+		MinPollInterval: clusterMinPollInterval,
+		MaxPollInterval: clusterMaxPollInterval,
+		NotFoundChecks:  clusterNotFoundChecks,
+	}
+	createdCluster, err := waiter.WaitForState(ctx)
+	if err != nil {
+		saveIncompleteCluster(ctx, resp, &plan, &clusterRequest, clusterId, createdCluster)
+		resp.Diagnostics.AddError("Fetching cluster",
+			fmt.Sprintf("Cluster [%s] did not become ready: %s", clusterId, err.Error()),
+		)
 		return
-	} else {
-		for createdCluster.Status != "READY" && createdCluster.Status != "FAILED" {
-			time.Sleep(10 * time.Second)
-			createdCluster, err = r.client.Controller.GetMdsCluster(createdCluster.ID)
-			if err != nil {
-				resp.Diagnostics.AddError("Fetching cluster",
-					"Could not fetch cluster by ID, unexpected error: "+err.Error(),
-				)
-				return
+	}
+
+	// the maintenance settings can only be applied to a ready cluster
+	if plan.MaintenanceWindow != nil || plan.PauseUpdates.ValueBool() {
+		maintainedCluster, err := r.updateMaintenance(ctx, clusterId, &plan)
+		if err != nil {
+			// keep the ready cluster in state, the next apply sets the maintenance settings it's missing
+			if saveFromResponse(&ctx, &resp.Diagnostics, &plan, createdCluster) == 0 {
+				resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 			}
+			addApiError(&resp.Diagnostics, "Setting maintenance of MDS Cluster",
+				"Could not set maintenance window of cluster, unexpected error: ", err, maintenanceFieldPaths)
+			return
 		}
+		createdCluster = maintainedCluster
 	}
 	tflog.Info(ctx, "INIT__Saving Response")
 	if saveFromResponse(&ctx, &resp.Diagnostics, &plan, createdCluster) != 0 {
@@ -436,10 +548,20 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultClusterReadTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Debug(ctx, "INIT_Read Fetching Cluster from API")
 	// Get refreshed cluster value from MDS
-	cluster, err := r.client.Controller.GetMdsCluster(state.ID.ValueString())
+	cluster, err := r.client.Controller.GetMdsCluster(ctx, state.ID.ValueString())
 	tflog.Debug(ctx, "INIT__Read fetched cluster", map[string]interface{}{"dto": cluster})
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading MDS Cluster",
@@ -454,6 +576,28 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	tflog.Debug(ctx, "INIT__Read fetching network policies")
+	policies, err := r.client.CustomerMetadata.GetAllPolicies(ctx, &customer_metadata.MdsPoliciesQuery{
+		Type:       policy_type.NETWORK,
+		ResourceId: state.ID.ValueString(),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Reading MDS Cluster",
+			fmt.Sprintf("Could not read network policies of MDS cluster [%s]: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+	policyIds := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		policyIds = append(policyIds, policy.ID)
+	}
+	networkPolicyIds, diags := types.SetValueFrom(ctx, types.StringType, policyIds)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	state.NetworkPolicyIds = networkPolicyIds
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -483,49 +627,95 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, clusterTimeout(state.ServiceType.ValueString()))
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Detect version change
-	if plan.Upgrade.TargetVersion != state.Version {
+	if !sameVersion(plan.Version.ValueString(), state.Version.ValueString()) {
 		tflog.Info(ctx, "Version change detected", map[string]interface{}{
 			"old_version": state.Version.ValueString(),
-			"new_version": plan.Upgrade.TargetVersion.ValueString(),
+			"new_version": plan.Version.ValueString(),
 		})
-
-		omitBackup := plan.Upgrade.OmitBackup.ValueBool()
-		// Generate API request to update the version
-		versionUpdateRequest := upgrade_service.UpdateMdsClusterVersionRequest{
-			Id:            state.ID.ValueString(),
-			TargetVersion: plan.Upgrade.TargetVersion.ValueString(),
-			RequestType:   "SERVICE",
-			Metadata:      upgrade_service.UpdateMdsClusterVersionRequestMetadata{OmitBackup: omitBackup},
+		omitBackup := plan.Upgrade != nil && plan.Upgrade.OmitBackup.ValueBool()
+		if _, err := upgradeCluster(ctx, r.client, state.ID.ValueString(), plan.Version.ValueString(), omitBackup); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				resp.Diagnostics.AddAttributeError(path.Root("version"), "Upgrading MDS Cluster",
+					fmt.Sprintf("Upgrade of cluster [%s] to version %s did not complete within %s, it may still be in progress in MDS. "+
+						"Refresh the cluster once it completed, or raise the update timeout: %s",
+						state.ID.ValueString(), plan.Version.ValueString(), updateTimeout, err.Error()),
+				)
+				return
+			}
+			addApiError(&resp.Diagnostics, "Upgrading MDS Cluster",
+				fmt.Sprintf("Could not upgrade cluster from version %s to %s: ", state.Version.ValueString(), plan.Version.ValueString()),
+				err, map[string]path.Path{"targetVersion": path.Root("version")})
+			return
 		}
+		tflog.Info(ctx, "Cluster version updated successfully")
+	}
 
-		fmt.Println(versionUpdateRequest)
+	// Detect instance size change
+	if !plan.InstanceSize.Equal(state.InstanceSize) {
+		tflog.Info(ctx, "Instance size change detected", map[string]interface{}{
+			"old_size": state.InstanceSize.ValueString(),
+			"new_size": plan.InstanceSize.ValueString(),
+		})
 
-		// Call the API to update the version
-		_, err := r.client.UpgradeService.UpdateMdsClusterVersion(state.ID.ValueString(), &versionUpdateRequest)
+		taskResponse, err := r.client.Controller.ResizeMdsCluster(ctx, state.ID.ValueString(), &controller.MdsClusterResizeRequest{
+			InstanceSize: plan.InstanceSize.ValueString(),
+		})
 		if err != nil {
+			addApiError(&resp.Diagnostics, "Resizing MDS Cluster",
+				"Could not resize cluster, unexpected error: ", err, clusterFieldPaths(state.ServiceType.ValueString()))
+			return
+		}
+
+		tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+		if _, err = r.client.TaskService.WaitForTask(ctx, taskResponse.TaskId); err != nil {
+			resp.Diagnostics.AddError("Resizing MDS Cluster",
+				fmt.Sprintf("Resize of cluster [%s] did not complete: %s", state.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		waiter := core.StateChangeConf[model.MdsCluster]{
+			Target:          []string{resource_status.READY},
+			Refresh:         clusterStateRefreshFunc(r.client, state.ID.ValueString()),
+			MinPollInterval: clusterMinPollInterval,
+			MaxPollInterval: clusterMaxPollInterval,
+		}
+		if _, err = waiter.WaitForState(ctx); err != nil {
+			resp.Diagnostics.AddError("Fetching cluster",
+				fmt.Sprintf("Cluster [%s] did not become ready after resize: %s", state.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+		tflog.Info(ctx, "Cluster resized successfully")
+	}
+
+	// Detect network policies change
+	if !plan.NetworkPolicyIds.Equal(state.NetworkPolicyIds) {
+		var policiesRequest controller.MdsClusterNetworkPoliciesUpdateRequest
+		plan.NetworkPolicyIds.ElementsAs(ctx, &policiesRequest.NetworkPolicyIds, true)
+		if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(ctx, state.ID.ValueString(), &policiesRequest); err != nil {
 			resp.Diagnostics.AddError(
-				"Updating Cluster Version",
-				"Could not update cluster version, unexpected error: "+err.Error(),
+				"Updating MDS Cluster",
+				"Could not update network policies of cluster, unexpected error: "+err.Error(),
 			)
 			return
 		}
+	}
 
-		// Wait for the version update to complete
-		for {
-			time.Sleep(10 * time.Second)
-			updatedCluster, err := r.client.Controller.GetMdsCluster(state.ID.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Fetching Updated Cluster",
-					"Could not fetch updated cluster by ID, unexpected error: "+err.Error(),
-				)
-				return
-			}
-			if updatedCluster.Version == plan.Version.ValueString() {
-				tflog.Info(ctx, "Cluster version updated successfully")
-				break
-			}
+	// Detect maintenance window or pausing of updates change
+	if maintenanceChanged(&plan, &state) {
+		if _, err := r.updateMaintenance(ctx, state.ID.ValueString(), &plan); err != nil {
+			addApiError(&resp.Diagnostics, "Updating MDS Cluster",
+				"Could not update maintenance window of cluster, unexpected error: ", err, maintenanceFieldPaths)
+			return
 		}
 	}
 
@@ -534,7 +724,7 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 	plan.Tags.ElementsAs(ctx, &updateRequest.Tags, true)
 
 	// Update existing cluster
-	cluster, err := r.client.Controller.UpdateMdsCluster(plan.ID.ValueString(), &updateRequest)
+	cluster, err := r.client.Controller.UpdateMdsCluster(ctx, plan.ID.ValueString(), &updateRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Updating MDS Cluster",
@@ -567,8 +757,19 @@ func (r *clusterResource) Delete(ctx context.Context, request resource.DeleteReq
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, clusterTimeout(state.ServiceType.ValueString()))
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Submit request to delete MDS Cluster
-	_, err := r.client.Controller.DeleteMdsCluster(state.ID.ValueString())
+	taskResponse, err := r.client.Controller.DeleteMdsCluster(ctx, state.ID.ValueString())
+	if core.IsNotFound(err) {
+		tflog.Info(ctx, "END__Delete cluster already removed")
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Deleting MDS Cluster",
@@ -577,30 +778,311 @@ func (r *clusterResource) Delete(ctx context.Context, request resource.DeleteReq
 		return
 	}
 
-	for {
-		time.Sleep(10 * time.Second)
-		if _, err := r.client.Controller.GetMdsCluster(state.ID.ValueString()); err != nil {
-			if err != nil {
-				var apiError core.ApiError
-				if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
-					break
-				}
-				resp.Diagnostics.AddError("Fetching cluster",
-					fmt.Sprintf("Could not fetch cluster by id [%v], unexpected error: %s", state.ID, err.Error()),
-				)
-				return
-			}
-		}
+	tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+	if _, err = r.client.TaskService.WaitForTask(ctx, taskResponse.TaskId); err != nil {
+		resp.Diagnostics.AddError("Deleting MDS Cluster",
+			fmt.Sprintf("Deletion of cluster [%s] did not complete: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	waiter := core.StateChangeConf[model.MdsCluster]{
+		Refresh:         clusterStateRefreshFunc(r.client, state.ID.ValueString()),
+		MinPollInterval: clusterMinPollInterval,
+		MaxPollInterval: clusterMaxPollInterval,
+	}
+	if _, err = waiter.WaitForState(ctx); err != nil {
+		resp.Diagnostics.AddError("Fetching cluster",
+			fmt.Sprintf("Cluster [%s] was not removed: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
 	}
 
 	tflog.Info(ctx, "END__Delete")
 }
 
+// ValidateConfig checks the `cluster_metadata` block set matches the service type, the time it restores to,
+// the maintenance window and the deprecated target version.
+func (r *clusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateClusterMetadata(ctx, req.Config, &resp.Diagnostics)
+	validateRestorePointInTime(ctx, req.Config, &resp.Diagnostics)
+	validateMaintenanceWindow(ctx, req.Config, &resp.Diagnostics)
+
+	// the deprecated target version can only repeat the version
+	var version, targetVersion types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("version"), &version)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("upgrade").AtName("target_version"), &targetVersion)...)
+	if resp.Diagnostics.HasError() || targetVersion.IsNull() || targetVersion.IsUnknown() || version.IsUnknown() {
+		return
+	}
+	if !version.Equal(targetVersion) {
+		resp.Diagnostics.AddAttributeError(path.Root("upgrade").AtName("target_version"), "Invalid upgrade",
+			fmt.Sprintf("Upgrades are driven by version, set version to %s instead of target_version.", targetVersion.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan explains a planned replacement of the cluster or an ignored change of its metadata, validates an upgrade
+// against the allowed targets, a new or changed instance size and, for a new cluster, the backup it's restored from.
+func (r *clusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	addReplacementWarnings(ctx, req, resp, "cluster", clusterImmutableAttributes)
+	if !req.State.Raw.IsNull() {
+		warnClusterMetadataChange(ctx, req, resp)
+		validateVersionChange(ctx, req, resp)
+	}
+	// nothing can be fetched before the provider is configured
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	r.validateInstanceSize(ctx, req, resp)
+	if req.State.Raw.IsNull() {
+		validateRestore(ctx, r.client, req.Plan, &resp.Diagnostics)
+	} else {
+		validateUpgradeTarget(ctx, req, resp, "Cluster", r.client.UpgradeService.GetMdsClusterUpgradeTargets)
+	}
+}
+
+// validateUpgradeTarget checks a changed version against the versions MDS allows the cluster or dataplane to be
+// upgraded to, as fetched by getTargets.
+func validateUpgradeTarget(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, kind string,
+	getTargets func(ctx context.Context, id string) (*model.MdsUpgradeTargets, error)) {
+	var id, version, stateVersion types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("version"), &version)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version"), &stateVersion)...)
+	if resp.Diagnostics.HasError() || version.IsUnknown() || version.IsNull() || sameVersion(version.ValueString(), stateVersion.ValueString()) {
+		return
+	}
+
+	upgradeTargets, err := getTargets(ctx, id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Validating upgrade",
+			"Could not fetch upgrade targets, the version is left to MDS to validate: "+err.Error(),
+		)
+		return
+	}
+	for _, targetVersion := range upgradeTargets.TargetVersions {
+		if sameVersion(version.ValueString(), targetVersion) {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid upgrade",
+		fmt.Sprintf("%s cannot be upgraded from %s to %s. Supported values: %s",
+			kind, stateVersion.ValueString(), version.ValueString(), strings.Join(upgradeTargets.TargetVersions, ", ")),
+	)
+}
+
+// resolveUpgradeTarget returns the version MDS offers to upgrade the cluster or dataplane to that the configured one
+// designates, e.g. 15.4 for 15, as fetched by getTargets. It's the configured version when the targets cannot be
+// fetched, MDS validates it then.
+func resolveUpgradeTarget(ctx context.Context, id string, version string,
+	getTargets func(ctx context.Context, id string) (*model.MdsUpgradeTargets, error)) string {
+	upgradeTargets, err := getTargets(ctx, id)
+	if err != nil {
+		tflog.Warn(ctx, "Could not fetch upgrade targets, submitting the version as configured", map[string]interface{}{
+			"id": id, "version": version, "error": err.Error(),
+		})
+		return version
+	}
+	return resolveVersion(version, upgradeTargets.TargetVersions)
+}
+
+// validateVersionChange checks a changed version of a cluster or dataplane is a legal upgrade of the current one.
+// The current version given by its leading numbers, e.g. 15 for 15.4, is no change.
+func validateVersionChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var version, stateVersion types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("version"), &version)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("version"), &stateVersion)...)
+	if resp.Diagnostics.HasError() || version.IsUnknown() || version.IsNull() || stateVersion.IsNull() ||
+		sameVersion(version.ValueString(), stateVersion.ValueString()) {
+		return
+	}
+	if err := validateUpgrade(stateVersion.ValueString(), version.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid upgrade", err.Error())
+	}
+}
+
+// validateInstanceSize checks a new or changed instance size against the instance types MDS offers for the service type.
+func (r *clusterResource) validateInstanceSize(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var instanceSize, serviceType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("instance_size"), &instanceSize)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_type"), &serviceType)...)
+	if resp.Diagnostics.HasError() || instanceSize.IsUnknown() || serviceType.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var stateInstanceSize types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("instance_size"), &stateInstanceSize)...)
+		if resp.Diagnostics.HasError() || stateInstanceSize.Equal(instanceSize) {
+			return
+		}
+	}
+
+	instanceTypes, err := r.client.Controller.GetServiceInstanceTypes(ctx, &controller.MdsInstanceTypesQuery{
+		ServiceType: serviceType.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddWarning("Validating instance size",
+			"Could not fetch instance types, the instance size is left to MDS to validate: "+err.Error(),
+		)
+		return
+	}
+	var supportedSizes []string
+	for _, instanceType := range instanceTypes.InstanceTypes {
+		if instanceType.InstanceSize == instanceSize.ValueString() {
+			return
+		}
+		supportedSizes = append(supportedSizes, instanceType.InstanceSize)
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("instance_size"), "Invalid instance size",
+		fmt.Sprintf("Instance size [%s] is not offered for service type %s. Supported values: %s",
+			instanceSize.ValueString(), serviceType.ValueString(), strings.Join(supportedSizes, ", ")),
+	)
+}
+
 func (r *clusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// upgradeCluster upgrades the cluster to the target version, and waits for MDS to report it's no longer upgrading.
+// A target given by its leading numbers is resolved to the version MDS offers, which is returned.
+func upgradeCluster(ctx context.Context, client *mds.Client, id string, targetVersion string, omitBackup bool) (string, error) {
+	targetVersion = resolveUpgradeTarget(ctx, id, targetVersion, client.UpgradeService.GetMdsClusterUpgradeTargets)
+	versionUpdateRequest := upgrade_service.UpdateMdsClusterVersionRequest{
+		Id:            id,
+		TargetVersion: targetVersion,
+		RequestType:   upgrade_request_type.SERVICE,
+	}
+	versionUpdateRequest.Metadata.OmitBackup = omitBackup
+	tflog.Debug(ctx, "Upgrading cluster", map[string]interface{}{"request": versionUpdateRequest})
+	if _, err := client.UpgradeService.UpdateMdsClusterVersion(ctx, id, &versionUpdateRequest); err != nil {
+		return targetVersion, err
+	}
+
+	// the cluster may report the upgrade only some time after it was submitted, it ended once it's reported done
+	refresh := clusterStateRefreshFunc(client, id)
+	started := false
+	waiter := core.StateChangeConf[model.MdsCluster]{
+		Pending: []string{upgradePending, upgradeInProgress},
+		Target:  []string{upgradeDone},
+		Refresh: func(ctx context.Context) (*model.MdsCluster, string, error) {
+			cluster, _, err := refresh(ctx)
+			if err != nil || cluster == nil {
+				return cluster, "", err
+			}
+			switch {
+			case cluster.UpgradeInProgress:
+				started = true
+				return cluster, upgradeInProgress, nil
+			case sameVersion(targetVersion, cluster.Version):
+				return cluster, upgradeDone, nil
+			case started:
+				return cluster, "", fmt.Errorf("upgrade ended with the cluster at version %s", cluster.Version)
+			}
+			return cluster, upgradePending, nil
+		},
+		MinPollInterval: clusterMinPollInterval,
+		MaxPollInterval: clusterMaxPollInterval,
+		OnRefresh: func(cluster *model.MdsCluster, state string) {
+			tflog.Info(ctx, "Upgrading cluster", map[string]interface{}{"id": id, "state": state, "version": cluster.Version})
+		},
+	}
+	_, err := waiter.WaitForState(ctx)
+	return targetVersion, err
+}
+
+// updateMaintenance sets the maintenance window and pausing of updates of the plan to the cluster
+func (r *clusterResource) updateMaintenance(ctx context.Context, id string, plan *clusterResourceModel) (*model.MdsCluster, error) {
+	maintenanceRequest, err := maintenanceRequest(plan.MaintenanceWindow, plan.PauseUpdates, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	tflog.Info(ctx, "Updating cluster maintenance", map[string]interface{}{"request": maintenanceRequest})
+	return r.client.Controller.UpdateMdsClusterMaintenance(ctx, id, maintenanceRequest)
+}
+
+// clusterTimeout returns the default timeout of a long-running operation on a cluster of the service type.
+func clusterTimeout(serviceType string) time.Duration {
+	if timeout, ok := defaultClusterTimeouts[serviceType]; ok {
+		return timeout
+	}
+	return defaultClusterTimeout
+}
+
+// clusterStateRefreshFunc refreshes the cluster for a waiter, a cluster which failed ends the wait with an error.
+func clusterStateRefreshFunc(client *mds.Client, id string) core.StateRefreshFunc[model.MdsCluster] {
+	return func(ctx context.Context) (*model.MdsCluster, string, error) {
+		cluster, err := client.Controller.GetMdsCluster(ctx, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if cluster.Status == resource_status.FAILED {
+			return cluster, cluster.Status, fmt.Errorf("cluster [%s] is in status %s", id, cluster.Status)
+		}
+		return cluster, cluster.Status, nil
+	}
+}
+
+// findCreatedCluster returns the ID of the cluster created by the request, empty when there is none by its name.
+func (r *clusterResource) findCreatedCluster(ctx context.Context, clusterRequest *controller.MdsClusterCreateRequest) (string, error) {
+	clusters, err := r.client.Controller.GetAllMdsClusters(ctx, &controller.MdsClustersQuery{
+		ServiceType:   clusterRequest.ServiceType,
+		Name:          clusterRequest.Name,
+		FullNameMatch: true,
+	}, func(cluster *model.MdsCluster) bool {
+		return cluster.Name == clusterRequest.Name
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(clusters) <= 0 || clusters[len(clusters)-1].Name != clusterRequest.Name {
+		return "", nil
+	}
+	return clusters[len(clusters)-1].ID, nil
+}
+
+// saveIncompleteCluster keeps track of a cluster whose creation did not complete, Terraform marks it tainted and
+// replaces it on the next apply. The cluster is saved as requested when MDS did not return it.
+func saveIncompleteCluster(ctx context.Context, resp *resource.CreateResponse, plan *clusterResourceModel,
+	clusterRequest *controller.MdsClusterCreateRequest, clusterId string, cluster *model.MdsCluster) {
+	if cluster == nil {
+		cluster = &model.MdsCluster{
+			ID:                clusterId,
+			Name:              clusterRequest.Name,
+			ServiceType:       clusterRequest.ServiceType,
+			Provider:          clusterRequest.Provider,
+			InstanceSize:      clusterRequest.InstanceSize,
+			Region:            clusterRequest.Region,
+			Tags:              clusterRequest.Tags,
+			Version:           clusterRequest.Version,
+			DataPlaneId:       clusterRequest.DataPlaneId,
+			StoragePolicyName: clusterRequest.StoragePolicyName,
+			Dedicated:         &clusterRequest.Dedicated,
+			Shared:            &clusterRequest.Shared,
+		}
+	}
+	if saveFromResponse(&ctx, &resp.Diagnostics, plan, cluster) == 0 {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	}
+}
+
+// boolFromResponse returns the value sent by MDS, or the current one when none was sent.
+func boolFromResponse(current types.Bool, value *bool) types.Bool {
+	if value != nil {
+		return types.BoolValue(*value)
+	}
+	if current.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return current
+}
+
 func saveFromResponse(ctx *context.Context, diagnostics *diag.Diagnostics, state *clusterResourceModel, cluster *model.MdsCluster) int8 {
 	tflog.Info(*ctx, "Saving response to resourceModel state/plan")
 	state.ID = types.StringValue(cluster.ID)
@@ -614,6 +1096,17 @@ func saveFromResponse(ctx *context.Context, diagnostics *diag.Diagnostics, state
 	state.DataPlaneId = types.StringValue(cluster.DataPlaneId)
 	state.LastUpdated = types.StringValue(cluster.LastUpdated)
 	state.Created = types.StringValue(cluster.Created)
+	// a version given by its leading numbers, e.g. 15 for 15.4, is kept as configured
+	if state.Version.IsUnknown() || cluster.Version != "" && !sameVersion(state.Version.ValueString(), cluster.Version) {
+		state.Version = types.StringValue(cluster.Version)
+	}
+	if cluster.StoragePolicyName != "" {
+		state.StoragePolicyName = types.StringValue(cluster.StoragePolicyName)
+	}
+	// dedicated and shared are not always sent back, the planned or saved value is kept then
+	state.Dedicated = boolFromResponse(state.Dedicated, cluster.Dedicated)
+	state.Shared = boolFromResponse(state.Shared, cluster.Shared)
+	saveMaintenanceFromResponse(state, cluster)
 	tflog.Info(*ctx, "trying to save mdsMetadata", map[string]interface{}{
 		"obj": cluster.Metadata,
 	})
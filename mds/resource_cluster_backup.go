@@ -0,0 +1,411 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/backup_status"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/backup_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBackupTimeout       = 60 * time.Minute
+	defaultBackupDeleteTimeout = 20 * time.Minute
+	backupMinPollInterval      = 10 * time.Second
+	backupMaxPollInterval      = 60 * time.Second
+	// a created backup may not be visible right away
+	backupNotFoundChecks = 3
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clusterBackupResource{}
+	_ resource.ResourceWithConfigure   = &clusterBackupResource{}
+	_ resource.ResourceWithImportState = &clusterBackupResource{}
+)
+
+func NewClusterBackupResource() resource.Resource {
+	return &clusterBackupResource{}
+}
+
+type clusterBackupResource struct {
+	client *mds.Client
+}
+
+type clusterBackupResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	ClusterId   types.String   `tfsdk:"cluster_id"`
+	Name        types.String   `tfsdk:"name"`
+	ClusterName types.String   `tfsdk:"cluster_name"`
+	ServiceType types.String   `tfsdk:"service_type"`
+	Version     types.String   `tfsdk:"version"`
+	Type        types.String   `tfsdk:"type"`
+	Size        types.String   `tfsdk:"size"`
+	Status      types.String   `tfsdk:"status"`
+	Created     types.String   `tfsdk:"created"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *clusterBackupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_backup"
+}
+
+func (r *clusterBackupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mds.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mds.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *clusterBackupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tflog.Info(ctx, "INIT__Schema")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents an on-demand backup of a cluster. It's created once the backup completed, so its `id` can be used as " +
+			"`restore_from` of a new cluster. A backup cannot be changed, changing `cluster_id` or `name` takes a new backup.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster to back up.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the backup.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				Description: "Name of the backed up cluster.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_type": schema.StringAttribute{
+				Description: "Service type of the backed up cluster.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Description: "Version of the backed up cluster, the backup can be restored to this version or a newer one.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the backup, `ON_DEMAND` for the backups taken by this resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.StringAttribute{
+				Description: "Size of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Description: "Creation time of the backup.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+
+	tflog.Info(ctx, "END__Schema")
+}
+
+// Create a new resource
+func (r *clusterBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "INIT__Create")
+	// Retrieve values from plan
+	var plan clusterBackupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultBackupTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	clusterId := plan.ClusterId.ValueString()
+	backupRequest := controller.MdsClusterBackupCreateRequest{
+		Name: plan.Name.ValueString(),
+	}
+	taskResponse, err := r.client.Controller.CreateMdsClusterBackup(ctx, clusterId, &backupRequest)
+	if err != nil {
+		addApiError(&resp.Diagnostics, "Submitting request to create backup",
+			"Could not create backup, unexpected error: ", err, map[string]path.Path{
+				"name": path.Root("name"),
+			})
+		return
+	}
+
+	tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+	task, err := r.client.TaskService.WaitForTask(ctx, taskResponse.TaskId)
+	if err != nil {
+		// the backup may exist even though its task did not complete, keep track of it
+		if task != nil && task.ResourceId != "" {
+			saveFromClusterBackupResponse(&plan, &model.MdsClusterBackup{Id: task.ResourceId, ClusterId: clusterId, Name: backupRequest.Name})
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		}
+		resp.Diagnostics.AddError("Creating backup",
+			fmt.Sprintf("Backup of cluster [%s] did not complete: %s", clusterId, err.Error()),
+		)
+		return
+	}
+
+	backupId := task.ResourceId
+	if backupId == "" {
+		if backupRequest.Name == "" {
+			resp.Diagnostics.AddError("Fetching backup",
+				fmt.Sprintf("MDS did not report the ID of the backup of cluster [%s]", clusterId),
+			)
+			return
+		}
+		backups, err := r.client.Controller.GetAllMdsClusterBackups(ctx, clusterId, &controller.MdsClusterBackupsQuery{BackupType: backup_type.ON_DEMAND},
+			func(backup *model.MdsClusterBackup) bool {
+				return backup.Name == backupRequest.Name
+			})
+		if err != nil {
+			resp.Diagnostics.AddError("Fetching backup",
+				"Could not fetch backups of the cluster, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if len(backups) <= 0 || backups[len(backups)-1].Name != backupRequest.Name {
+			resp.Diagnostics.AddError("Fetching backup",
+				"Unable to fetch the created backup",
+			)
+			return
+		}
+		backupId = backups[len(backups)-1].Id
+	}
+
+	waiter := core.StateChangeConf[model.MdsClusterBackup]{
+		Target:          []string{backup_status.COMPLETED},
+		Refresh:         clusterBackupStateRefreshFunc(r.client, clusterId, backupId),
+		MinPollInterval: backupMinPollInterval,
+		MaxPollInterval: backupMaxPollInterval,
+		NotFoundChecks:  backupNotFoundChecks,
+	}
+	backup, err := waiter.WaitForState(ctx)
+	if err != nil {
+		// keep track of the backup taken, Terraform marks it tainted and replaces it on the next apply
+		if backup == nil {
+			backup = &model.MdsClusterBackup{Id: backupId, ClusterId: clusterId, Name: backupRequest.Name}
+		}
+		saveFromClusterBackupResponse(&plan, backup)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.AddError("Fetching backup",
+			fmt.Sprintf("Backup [%s] did not complete: %s", backupId, err.Error()),
+		)
+		return
+	}
+
+	saveFromClusterBackupResponse(&plan, backup)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Create")
+}
+
+// Read resource information
+func (r *clusterBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "INIT__Read")
+	// Get current state
+	var state clusterBackupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backup, err := r.client.Controller.GetMdsClusterBackup(ctx, state.ClusterId.ValueString(), state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Reading MDS Cluster Backup",
+			"Could not read MDS cluster backup ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveFromClusterBackupResponse(&state, backup)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Read")
+}
+
+func (r *clusterBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "INIT__Update")
+
+	var plan, state clusterBackupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// any other change replaces the backup
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	tflog.Info(ctx, "END__Update")
+}
+
+func (r *clusterBackupResource) Delete(ctx context.Context, request resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "INIT__Delete")
+	// Get current state
+	var state clusterBackupResourceModel
+	diags := request.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultBackupDeleteTimeout)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	taskResponse, err := r.client.Controller.DeleteMdsClusterBackup(ctx, state.ClusterId.ValueString(), state.ID.ValueString())
+	if core.IsNotFound(err) {
+		tflog.Info(ctx, "END__Delete backup already removed")
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Deleting MDS Cluster Backup",
+			"Could not delete MDS cluster backup by ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "INIT__Following task", map[string]interface{}{"task_id": taskResponse.TaskId})
+	if _, err = r.client.TaskService.WaitForTask(ctx, taskResponse.TaskId); err != nil {
+		resp.Diagnostics.AddError("Deleting MDS Cluster Backup",
+			fmt.Sprintf("Deletion of backup [%s] did not complete: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "END__Delete")
+}
+
+// ImportState imports a backup by `<cluster_id>/<backup_id>`
+func (r *clusterBackupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterId, backupId, found := strings.Cut(req.ID, "/")
+	if !found || clusterId == "" || backupId == "" {
+		resp.Diagnostics.AddError("Importing MDS Cluster Backup",
+			fmt.Sprintf("Expected import ID in the format <cluster_id>/<backup_id>, got: %s", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), backupId)...)
+}
+
+// clusterBackupStateRefreshFunc refreshes the backup for a waiter, a backup which failed ends the wait with an error.
+func clusterBackupStateRefreshFunc(client *mds.Client, clusterId string, id string) core.StateRefreshFunc[model.MdsClusterBackup] {
+	return func(ctx context.Context) (*model.MdsClusterBackup, string, error) {
+		backup, err := client.Controller.GetMdsClusterBackup(ctx, clusterId, id)
+		if err != nil {
+			return nil, "", err
+		}
+		if backup.Status == backup_status.FAILED {
+			return backup, backup.Status, fmt.Errorf("backup [%s] is in status %s", id, backup.Status)
+		}
+		return backup, backup.Status, nil
+	}
+}
+
+func saveFromClusterBackupResponse(state *clusterBackupResourceModel, backup *model.MdsClusterBackup) {
+	state.ID = types.StringValue(backup.Id)
+	if backup.ClusterId != "" {
+		state.ClusterId = types.StringValue(backup.ClusterId)
+	}
+	state.Name = types.StringValue(backup.Name)
+	state.ClusterName = types.StringValue(backup.ClusterName)
+	state.ServiceType = types.StringValue(backup.ServiceType)
+	state.Version = types.StringValue(backup.Version)
+	state.Type = types.StringValue(backup.BackupType)
+	state.Size = types.StringValue(backup.Size)
+	state.Status = types.StringValue(backup.Status)
+	state.Created = types.StringValue(backup.Created)
+}
@@ -0,0 +1,303 @@
+package mds
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"regexp"
+)
+
+var (
+	// cronSchedulePattern matches the five fields of a cron expression: minute, hour, day of month, month and day of week.
+	cronSchedulePattern = regexp.MustCompile(`^\S+(\s+\S+){4}$`)
+	timeOfDayPattern    = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &clusterBackupPolicyResource{}
+	_ resource.ResourceWithConfigure        = &clusterBackupPolicyResource{}
+	_ resource.ResourceWithImportState      = &clusterBackupPolicyResource{}
+	_ resource.ResourceWithConfigValidators = &clusterBackupPolicyResource{}
+)
+
+func NewClusterBackupPolicyResource() resource.Resource {
+	return &clusterBackupPolicyResource{}
+}
+
+type clusterBackupPolicyResource struct {
+	client *mds.Client
+}
+
+type clusterBackupPolicyResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ClusterId      types.String `tfsdk:"cluster_id"`
+	CronSchedule   types.String `tfsdk:"cron_schedule"`
+	DailyWindow    types.String `tfsdk:"daily_window"`
+	RetentionCount types.Int64  `tfsdk:"retention_count"`
+	RetentionDays  types.Int64  `tfsdk:"retention_days"`
+	PitrEnabled    types.Bool   `tfsdk:"pitr_enabled"`
+}
+
+func (r *clusterBackupPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_backup_policy"
+}
+
+func (r *clusterBackupPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mds.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mds.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *clusterBackupPolicyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tflog.Info(ctx, "INIT__Schema")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Represents the schedule and retention of the automated backups of a cluster. A cluster has a single backup policy, " +
+			"destroying this resource resets it to the default policy of the service.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the backup policy, same as the ID of the cluster.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "ID of the cluster the backup policy applies to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cron_schedule": schema.StringAttribute{
+				MarkdownDescription: "Cron expression in UTC of when the backups are taken, e.g. `0 2 * * *`. Exactly one of `cron_schedule` and `daily_window` is required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cronSchedulePattern, "must be a cron expression of five fields"),
+				},
+			},
+			"daily_window": schema.StringAttribute{
+				MarkdownDescription: "Start of the daily backup window in UTC, as `HH:MM`. Exactly one of `cron_schedule` and `daily_window` is required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day as HH:MM"),
+				},
+			},
+			"retention_count": schema.Int64Attribute{
+				Description: "Number of the automated backups to keep.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retention_days": schema.Int64Attribute{
+				Description: "Number of days the automated backups are kept for.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pitr_enabled": schema.BoolAttribute{
+				Description: "Whether point-in-time recovery is enabled for the cluster. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+
+	tflog.Info(ctx, "END__Schema")
+}
+
+// ConfigValidators requires the schedule given either as a cron expression or as a daily window.
+func (r *clusterBackupPolicyResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("cron_schedule"), path.MatchRoot("daily_window")),
+	}
+}
+
+// Create replaces the backup policy of the cluster by the configured one
+func (r *clusterBackupPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "INIT__Create")
+	// Retrieve values from plan
+	var plan clusterBackupPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.Controller.UpdateMdsClusterBackupPolicy(ctx, plan.ClusterId.ValueString(), plan.request())
+	if err != nil {
+		addApiError(&resp.Diagnostics, "Submitting request to set backup policy",
+			"Could not set backup policy, unexpected error: ", err, clusterBackupPolicyFieldPaths)
+		return
+	}
+
+	saveFromClusterBackupPolicyResponse(&plan, policy)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Create")
+}
+
+// Read resource information
+func (r *clusterBackupPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "INIT__Read")
+	// Get current state
+	var state clusterBackupPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.Controller.GetMdsClusterBackupPolicy(ctx, state.ClusterId.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Reading MDS Cluster Backup Policy",
+			"Could not read backup policy of MDS cluster ID "+state.ClusterId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// a schedule changed in the MDS console shows as drift
+	saveFromClusterBackupPolicyResponse(&state, policy)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Read")
+}
+
+func (r *clusterBackupPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "INIT__Update")
+	// Retrieve values from plan
+	var plan clusterBackupPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.Controller.UpdateMdsClusterBackupPolicy(ctx, plan.ClusterId.ValueString(), plan.request())
+	if err != nil {
+		addApiError(&resp.Diagnostics, "Updating MDS Cluster Backup Policy",
+			"Could not update backup policy, unexpected error: ", err, clusterBackupPolicyFieldPaths)
+		return
+	}
+
+	saveFromClusterBackupPolicyResponse(&plan, policy)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Update")
+}
+
+// Delete resets the backup policy of the cluster to the default one
+func (r *clusterBackupPolicyResource) Delete(ctx context.Context, request resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "INIT__Delete")
+	// Get current state
+	var state clusterBackupPolicyResourceModel
+	diags := request.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Controller.DeleteMdsClusterBackupPolicy(ctx, state.ClusterId.ValueString())
+	if err != nil && !core.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Deleting MDS Cluster Backup Policy",
+			"Could not reset backup policy of MDS cluster ID "+state.ClusterId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "END__Delete")
+}
+
+// ImportState imports the backup policy by the ID of its cluster
+func (r *clusterBackupPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), req.ID)...)
+}
+
+var clusterBackupPolicyFieldPaths = map[string]path.Path{
+	"cronSchedule":   path.Root("cron_schedule"),
+	"dailyWindow":    path.Root("daily_window"),
+	"retentionCount": path.Root("retention_count"),
+	"retentionDays":  path.Root("retention_days"),
+	"pitrEnabled":    path.Root("pitr_enabled"),
+}
+
+func (m *clusterBackupPolicyResourceModel) request() *controller.MdsClusterBackupPolicyUpdateRequest {
+	return &controller.MdsClusterBackupPolicyUpdateRequest{
+		CronSchedule:   m.CronSchedule.ValueString(),
+		DailyWindow:    m.DailyWindow.ValueString(),
+		RetentionCount: m.RetentionCount.ValueInt64(),
+		RetentionDays:  m.RetentionDays.ValueInt64(),
+		PitrEnabled:    m.PitrEnabled.ValueBool(),
+	}
+}
+
+func saveFromClusterBackupPolicyResponse(state *clusterBackupPolicyResourceModel, policy *model.MdsClusterBackupPolicy) {
+	if policy.ClusterId != "" {
+		state.ClusterId = types.StringValue(policy.ClusterId)
+	}
+	state.ID = state.ClusterId
+	state.CronSchedule = types.StringNull()
+	if policy.CronSchedule != "" {
+		state.CronSchedule = types.StringValue(policy.CronSchedule)
+	}
+	state.DailyWindow = types.StringNull()
+	if policy.DailyWindow != "" {
+		state.DailyWindow = types.StringValue(policy.DailyWindow)
+	}
+	state.RetentionCount = types.Int64Value(policy.RetentionCount)
+	state.RetentionDays = types.Int64Value(policy.RetentionDays)
+	state.PitrEnabled = types.BoolValue(policy.PitrEnabled)
+}
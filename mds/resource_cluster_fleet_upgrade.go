@@ -0,0 +1,516 @@
+package mds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// results of the upgrade of a cluster of the fleet
+const (
+	fleetClusterUpgraded = "UPGRADED"
+	fleetClusterUpToDate = "UP_TO_DATE"
+	fleetClusterFailed   = "FAILED"
+	fleetClusterSkipped  = "SKIPPED"
+)
+
+var fleetClusterAttrTypes = map[string]attr.Type{
+	"id":               types.StringType,
+	"name":             types.StringType,
+	"previous_version": types.StringType,
+	"version":          types.StringType,
+	"result":           types.StringType,
+	"error":            types.StringType,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &clusterFleetUpgradeResource{}
+	_ resource.ResourceWithConfigure = &clusterFleetUpgradeResource{}
+)
+
+func NewClusterFleetUpgradeResource() resource.Resource {
+	return &clusterFleetUpgradeResource{}
+}
+
+type clusterFleetUpgradeResource struct {
+	client *mds.Client
+}
+
+type clusterFleetUpgradeResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	ServiceType      types.String   `tfsdk:"service_type"`
+	Tags             types.Set      `tfsdk:"tags"`
+	TargetVersion    types.String   `tfsdk:"target_version"`
+	BatchSize        types.Int64    `tfsdk:"batch_size"`
+	CanaryClusterIds types.Set      `tfsdk:"canary_cluster_ids"`
+	OmitBackup       types.Bool     `tfsdk:"omit_backup"`
+	Clusters         types.List     `tfsdk:"clusters"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+type fleetClusterModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	PreviousVersion types.String `tfsdk:"previous_version"`
+	Version         types.String `tfsdk:"version"`
+	Result          types.String `tfsdk:"result"`
+	Error           types.String `tfsdk:"error"`
+}
+
+func (r *clusterFleetUpgradeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_fleet_upgrade"
+}
+
+func (r *clusterFleetUpgradeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mds.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mds.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *clusterFleetUpgradeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tflog.Info(ctx, "INIT__Schema")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Upgrades all clusters of a service type carrying the given tags to a version, batch by batch. " +
+			"The canary clusters are upgraded first, and the upgrade stops at the first batch with a failed cluster. " +
+			"Changing `target_version` upgrades the fleet again, destroying this resource leaves the clusters as they are.\n" +
+			"## Note:\n" +
+			"- Clusters tagged after the upgrade are only upgraded once `target_version` changes, or the resource is replaced.\n" +
+			"- A failed upgrade keeps the previous `target_version` in the state, so the next apply resumes it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the fleet, made of the service type and the tags.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the service of the clusters to upgrade, one of `" + strings.Join(service_type.GetAll(), "`, `") + "`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(service_type.GetAll()...),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Description: "Tags the clusters to upgrade all carry.",
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"target_version": schema.StringAttribute{
+				Description: "Version to upgrade the clusters to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(serviceVersionPattern, "must be a semantic version, e.g. 3.11.2"),
+				},
+			},
+			"batch_size": schema.Int64Attribute{
+				Description: "Number of clusters upgraded at the same time. Defaults to `1`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"canary_cluster_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the clusters of the fleet to upgrade before the others. The first cluster by name which isn't at `target_version` yet when not set.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"omit_backup": schema.BoolAttribute{
+				Description: "If present and set to `true`, no backup of the clusters is taken before they're upgraded.",
+				Optional:    true,
+			},
+			"clusters": schema.ListNestedAttribute{
+				MarkdownDescription: "Result of the upgrade of each cluster of the fleet, in the order they were upgraded in.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the cluster.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the cluster.",
+							Computed:    true,
+						},
+						"previous_version": schema.StringAttribute{
+							Description: "Version of the cluster before the upgrade.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "Version of the cluster.",
+							Computed:    true,
+						},
+						"result": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("Result of the upgrade of the cluster, one of `%s`, `%s`, `%s` and `%s`.",
+								fleetClusterUpgraded, fleetClusterUpToDate, fleetClusterFailed, fleetClusterSkipped),
+							Computed: true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Why the upgrade of the cluster failed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+
+	tflog.Info(ctx, "END__Schema")
+}
+
+// Create upgrades the fleet
+func (r *clusterFleetUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "INIT__Create")
+	// Retrieve values from plan
+	var plan clusterFleetUpgradeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upgradeFleet(ctx, &plan, plan.Timeouts.Create, &resp.Diagnostics)
+	if plan.Clusters.IsUnknown() {
+		return
+	}
+
+	// a failed upgrade is saved as well, to report the result of each cluster
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Create")
+}
+
+// Read refreshes the version of the clusters of the fleet
+func (r *clusterFleetUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "INIT__Read")
+	// Get current state
+	var state clusterFleetUpgradeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var clusters []fleetClusterModel
+	resp.Diagnostics.Append(state.Clusters.ElementsAs(ctx, &clusters, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshed := make([]fleetClusterModel, 0, len(clusters))
+	for _, cluster := range clusters {
+		clusterDto, err := r.client.Controller.GetMdsCluster(ctx, cluster.ID.ValueString())
+		if core.IsNotFound(err) {
+			tflog.Info(ctx, "Cluster of the fleet no longer exists", map[string]interface{}{"id": cluster.ID.ValueString()})
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Reading MDS Cluster Fleet Upgrade",
+				"Could not read MDS cluster ID "+cluster.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		cluster.Version = types.StringValue(clusterDto.Version)
+		refreshed = append(refreshed, cluster)
+	}
+	state.Clusters, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: fleetClusterAttrTypes}, refreshed)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Read")
+}
+
+// Update upgrades the fleet again, to the changed target version or with the changed ordering
+func (r *clusterFleetUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "INIT__Update")
+	// Retrieve values from plan
+	var plan clusterFleetUpgradeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	var priorTargetVersion types.String
+	diags = req.State.GetAttribute(ctx, path.Root("target_version"), &priorTargetVersion)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.upgradeFleet(ctx, &plan, plan.Timeouts.Update, &resp.Diagnostics)
+	if plan.Clusters.IsUnknown() {
+		return
+	}
+	// the fleet only reached the target version once every cluster did, the next apply resumes the upgrade
+	if resp.Diagnostics.HasError() {
+		plan.TargetVersion = priorTargetVersion
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "END__Update")
+}
+
+// Delete only removes the fleet upgrade from the state, upgrades cannot be undone
+func (r *clusterFleetUpgradeResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "INIT__Delete")
+	tflog.Info(ctx, "Clusters of the fleet are left at their version")
+	tflog.Info(ctx, "END__Delete")
+}
+
+// upgradeFleet upgrades the clusters of the fleet not at the target version yet, canaries first, a batch at a time.
+// It sets the ID and the result of each cluster to the plan, and leaves them unknown when nothing was upgraded.
+func (r *clusterFleetUpgradeResource) upgradeFleet(ctx context.Context, plan *clusterFleetUpgradeResourceModel,
+	timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), diagnostics *diag.Diagnostics) {
+	var tags, canaryIds []string
+	diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+	diagnostics.Append(plan.CanaryClusterIds.ElementsAs(ctx, &canaryIds, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+	sort.Strings(tags)
+	serviceType := plan.ServiceType.ValueString()
+	targetVersion := plan.TargetVersion.ValueString()
+
+	clusters, err := r.client.Controller.GetAllMdsClusters(ctx, &controller.MdsClustersQuery{ServiceType: serviceType}, nil)
+	if err != nil {
+		diagnostics.AddError("Fetching clusters",
+			"Could not fetch the clusters of the fleet, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	fleet := selectFleet(clusters, tags)
+	if len(fleet) == 0 {
+		diagnostics.AddWarning("Upgrading fleet",
+			fmt.Sprintf("No %s cluster carries all of the tags %s, there's nothing to upgrade.", serviceType, strings.Join(tags, ", ")),
+		)
+	}
+	fleet, err = orderFleet(fleet, canaryIds)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("canary_cluster_ids"), "Invalid canary clusters", err.Error())
+		return
+	}
+
+	// every cluster is checked before any is upgraded, a fleet is not left half upgraded for a cluster which cannot be
+	results := make([]fleetClusterModel, len(fleet))
+	var pending []int
+	for i, cluster := range fleet {
+		results[i] = fleetClusterModel{
+			ID:              types.StringValue(cluster.ID),
+			Name:            types.StringValue(cluster.Name),
+			PreviousVersion: types.StringValue(cluster.Version),
+			Version:         types.StringValue(cluster.Version),
+			Result:          types.StringValue(fleetClusterUpToDate),
+			Error:           types.StringNull(),
+		}
+		if sameVersion(targetVersion, cluster.Version) {
+			continue
+		}
+		if err := validateUpgrade(cluster.Version, targetVersion); err != nil {
+			diagnostics.AddAttributeError(path.Root("target_version"), "Invalid upgrade",
+				fmt.Sprintf("Cluster %s [%s] cannot be upgraded: %s", cluster.Name, cluster.ID, err.Error()),
+			)
+		}
+		pending = append(pending, i)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+
+	batchSize := 1
+	if !plan.BatchSize.IsNull() {
+		batchSize = int(plan.BatchSize.ValueInt64())
+	}
+	batches := fleetBatches(pending, batchSize, len(canaryIds))
+	upgradeTimeout, diags := timeout(ctx, clusterTimeout(serviceType)*time.Duration(len(batches)))
+	if diagnostics.Append(diags...); diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, upgradeTimeout)
+	defer cancel()
+
+	var failed bool
+	for _, batch := range batches {
+		if failed {
+			for _, i := range batch {
+				results[i].Result = types.StringValue(fleetClusterSkipped)
+			}
+			continue
+		}
+		tflog.Info(ctx, "Upgrading batch of the fleet", map[string]interface{}{"size": len(batch)})
+		errs := make([]error, len(batch))
+		versions := make([]string, len(batch))
+		var wg sync.WaitGroup
+		for j, i := range batch {
+			wg.Add(1)
+			go func(j int, id string) {
+				defer wg.Done()
+				versions[j], errs[j] = upgradeCluster(ctx, r.client, id, targetVersion, plan.OmitBackup.ValueBool())
+			}(j, fleet[i].ID)
+		}
+		wg.Wait()
+
+		for j, i := range batch {
+			if errs[j] == nil {
+				results[i].Version = types.StringValue(versions[j])
+				results[i].Result = types.StringValue(fleetClusterUpgraded)
+				continue
+			}
+			failed = true
+			results[i].Result = types.StringValue(fleetClusterFailed)
+			results[i].Error = types.StringValue(errs[j].Error())
+			detail := fmt.Sprintf("Could not upgrade cluster %s [%s] from version %s to %s, the clusters not upgraded yet are skipped: %s",
+				fleet[i].Name, fleet[i].ID, fleet[i].Version, targetVersion, errs[j].Error())
+			if errors.Is(errs[j], context.DeadlineExceeded) {
+				detail += fmt.Sprintf(". The upgrade did not complete within %s, it may still be in progress in MDS", upgradeTimeout)
+			}
+			diagnostics.AddError("Upgrading fleet", detail)
+		}
+	}
+
+	plan.ID = types.StringValue(serviceType + "/" + strings.Join(tags, ","))
+	clusterList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: fleetClusterAttrTypes}, results)
+	diagnostics.Append(diags...)
+	plan.Clusters = clusterList
+}
+
+// selectFleet returns the clusters carrying all the tags, by name.
+func selectFleet(clusters []model.MdsCluster, tags []string) []model.MdsCluster {
+	var fleet []model.MdsCluster
+	for _, cluster := range clusters {
+		carried := map[string]bool{}
+		for _, tag := range cluster.Tags {
+			carried[tag] = true
+		}
+		selected := true
+		for _, tag := range tags {
+			selected = selected && carried[tag]
+		}
+		if selected {
+			fleet = append(fleet, cluster)
+		}
+	}
+	sort.SliceStable(fleet, func(i, j int) bool {
+		return fleet[i].Name < fleet[j].Name
+	})
+	return fleet
+}
+
+// orderFleet moves the canary clusters to the front of the fleet, keeping the fleet by name otherwise.
+func orderFleet(fleet []model.MdsCluster, canaryIds []string) ([]model.MdsCluster, error) {
+	canaries := map[string]bool{}
+	for _, id := range canaryIds {
+		canaries[id] = true
+	}
+	var ordered, others []model.MdsCluster
+	for _, cluster := range fleet {
+		if canaries[cluster.ID] {
+			ordered = append(ordered, cluster)
+			delete(canaries, cluster.ID)
+		} else {
+			others = append(others, cluster)
+		}
+	}
+	if len(canaries) > 0 {
+		var missing []string
+		for id := range canaries {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("clusters [%s] are not part of the fleet", strings.Join(missing, ", "))
+	}
+	return append(ordered, others...), nil
+}
+
+// fleetBatches splits the indexes of the clusters to upgrade into batches of at most batchSize, the canaries at the front
+// forming batches of their own. The first cluster to upgrade is the canary when none is given.
+func fleetBatches(pending []int, batchSize int, canaryCount int) [][]int {
+	var batches [][]int
+	split := func(indexes []int) {
+		for len(indexes) > 0 {
+			size := batchSize
+			if size > len(indexes) {
+				size = len(indexes)
+			}
+			batches = append(batches, indexes[:size])
+			indexes = indexes[size:]
+		}
+	}
+	// the canaries are at the front of the fleet, which the pending indexes are in the order of
+	canaryEnd := 0
+	if canaryCount == 0 && len(pending) > 0 {
+		canaryEnd = 1
+	}
+	for canaryEnd < len(pending) && pending[canaryEnd] < canaryCount {
+		canaryEnd++
+	}
+	split(pending[:canaryEnd])
+	split(pending[canaryEnd:])
+	return batches
+}
@@ -0,0 +1,107 @@
+package mds
+
+import (
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"reflect"
+	"testing"
+)
+
+func TestFleetUpgradeOrder(t *testing.T) {
+	clusters := []model.MdsCluster{
+		{ID: "id-d", Name: "delta", Version: "15.3", Tags: []string{"prod", "eu"}},
+		{ID: "id-a", Name: "alpha", Version: "15.3", Tags: []string{"prod"}},
+		{ID: "id-c", Name: "charlie", Version: "15.4", Tags: []string{"prod"}},
+		{ID: "id-b", Name: "bravo", Version: "15.3", Tags: []string{"prod"}},
+		{ID: "id-e", Name: "echo", Version: "15.3", Tags: []string{"dev"}},
+	}
+	tests := map[string]struct {
+		tags        []string
+		canaryIds   []string
+		batchSize   int
+		wantFleet   []string
+		wantBatches [][]string
+		wantErr     bool
+	}{
+		"no canaries, the first cluster is one": {
+			tags:        []string{"prod"},
+			batchSize:   2,
+			wantFleet:   []string{"id-a", "id-b", "id-c", "id-d"},
+			wantBatches: [][]string{{"id-a"}, {"id-b", "id-d"}},
+		},
+		"canaries first": {
+			tags:        []string{"prod"},
+			canaryIds:   []string{"id-d", "id-b"},
+			batchSize:   1,
+			wantFleet:   []string{"id-b", "id-d", "id-a", "id-c"},
+			wantBatches: [][]string{{"id-b"}, {"id-d"}, {"id-a"}},
+		},
+		"canaries already at target": {
+			tags:        []string{"prod"},
+			canaryIds:   []string{"id-c"},
+			batchSize:   2,
+			wantFleet:   []string{"id-c", "id-a", "id-b", "id-d"},
+			wantBatches: [][]string{{"id-a", "id-b"}, {"id-d"}},
+		},
+		"batch size beyond the fleet": {
+			tags:        []string{"prod"},
+			canaryIds:   []string{"id-a"},
+			batchSize:   10,
+			wantFleet:   []string{"id-a", "id-b", "id-c", "id-d"},
+			wantBatches: [][]string{{"id-a"}, {"id-b", "id-d"}},
+		},
+		"all tags carried": {
+			tags:        []string{"eu", "prod"},
+			batchSize:   1,
+			wantFleet:   []string{"id-d"},
+			wantBatches: [][]string{{"id-d"}},
+		},
+		"no cluster tagged": {
+			tags:      []string{"staging"},
+			batchSize: 1,
+		},
+		"unknown canary": {
+			tags:      []string{"prod"},
+			canaryIds: []string{"id-a", "id-x", "id-e"},
+			batchSize: 1,
+			wantErr:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fleet, err := orderFleet(selectFleet(clusters, test.tags), test.canaryIds)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got: %v", test.wantErr, err)
+			}
+			if err != nil {
+				if err.Error() != "clusters [id-e, id-x] are not part of the fleet" {
+					t.Errorf("expected the unknown canaries to be reported, got: %v", err)
+				}
+				return
+			}
+
+			var fleetIds []string
+			var pending []int
+			for i, cluster := range fleet {
+				fleetIds = append(fleetIds, cluster.ID)
+				if cluster.Version != "15.4" {
+					pending = append(pending, i)
+				}
+			}
+			if !reflect.DeepEqual(fleetIds, test.wantFleet) {
+				t.Errorf("expected fleet %v, got %v", test.wantFleet, fleetIds)
+			}
+
+			var batchIds [][]string
+			for _, batch := range fleetBatches(pending, test.batchSize, len(test.canaryIds)) {
+				var ids []string
+				for _, i := range batch {
+					ids = append(ids, fleet[i].ID)
+				}
+				batchIds = append(batchIds, ids)
+			}
+			if !reflect.DeepEqual(batchIds, test.wantBatches) {
+				t.Errorf("expected batches %v, got %v", test.wantBatches, batchIds)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/policy_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/controller"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	customer_metadata "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 )
 
@@ -97,7 +98,7 @@ func (r *clusterNetworkPoliciesAssociationResource) Create(ctx context.Context,
 		NetworkPolicyIds: plan.PolicyIds,
 	}
 	//plan.PolicyIds.ElementsAs(ctx, &updateRequest.NetworkPolicyIds, true)
-	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(plan.ID.ValueString(), &updateRequest); err != nil {
+	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(ctx, plan.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Creating cluster network policies association",
 			"Could not create association, unexpected error: "+err.Error(),
@@ -126,10 +127,13 @@ func (r *clusterNetworkPoliciesAssociationResource) Read(ctx context.Context, re
 	}
 
 	// Get refreshed cluster value from MDS
-	policies, err := r.client.CustomerMetadata.GetPolicies(&customer_metadata.MdsPoliciesQuery{
+	policies, err := r.client.CustomerMetadata.GetAllPolicies(ctx, &customer_metadata.MdsPoliciesQuery{
 		Type:       policy_type.NETWORK,
 		ResourceId: state.ID.ValueString(),
-	})
+	}, nil)
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading Cluster Network Policies",
@@ -139,7 +143,7 @@ func (r *clusterNetworkPoliciesAssociationResource) Read(ctx context.Context, re
 	}
 
 	state.PolicyIds = []string{}
-	for _, item := range *policies.Get() {
+	for _, item := range policies {
 		state.PolicyIds = append(state.PolicyIds, item.ID)
 	}
 
@@ -165,7 +169,7 @@ func (r *clusterNetworkPoliciesAssociationResource) Update(ctx context.Context,
 	updateRequest := controller.MdsClusterNetworkPoliciesUpdateRequest{
 		NetworkPolicyIds: plan.PolicyIds,
 	}
-	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(plan.ID.ValueString(), &updateRequest); err != nil {
+	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(ctx, plan.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating cluster network policies association",
 			"Could not update association, unexpected error: "+err.Error(),
@@ -194,7 +198,7 @@ func (r *clusterNetworkPoliciesAssociationResource) Delete(ctx context.Context,
 	updateRequest := controller.MdsClusterNetworkPoliciesUpdateRequest{
 		NetworkPolicyIds: []string{},
 	}
-	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(plan.ID.ValueString(), &updateRequest); err != nil {
+	if _, err := r.client.Controller.UpdateMdsClusterNetworkPolicies(ctx, plan.ID.ValueString(), &updateRequest); err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting cluster network policies association",
 			"Could not delete association, unexpected error: "+err.Error(),
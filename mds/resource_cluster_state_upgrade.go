@@ -0,0 +1,171 @@
+package mds
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+)
+
+// clusterResourceModelV0 maps the cluster state of schema version 0, when `cluster_metadata` held the
+// settings of a Postgres cluster whatever the service type.
+type clusterResourceModelV0 struct {
+	ID                types.String            `tfsdk:"id"`
+	OrgId             types.String            `tfsdk:"org_id"`
+	Name              types.String            `tfsdk:"name"`
+	ServiceType       types.String            `tfsdk:"service_type"`
+	Provider          types.String            `tfsdk:"cloud_provider"`
+	InstanceSize      types.String            `tfsdk:"instance_size"`
+	Region            types.String            `tfsdk:"region"`
+	Tags              types.Set               `tfsdk:"tags"`
+	NetworkPolicyIds  types.Set               `tfsdk:"network_policy_ids"`
+	Dedicated         types.Bool              `tfsdk:"dedicated"`
+	Shared            types.Bool              `tfsdk:"shared"`
+	Status            types.String            `tfsdk:"status"`
+	DataPlaneId       types.String            `tfsdk:"data_plane_id"`
+	LastUpdated       types.String            `tfsdk:"last_updated"`
+	Created           types.String            `tfsdk:"created"`
+	Metadata          types.Object            `tfsdk:"metadata"`
+	Version           types.String            `tfsdk:"version"`
+	StoragePolicyName types.String            `tfsdk:"storage_policy_name"`
+	ClusterMetadata   *clusterMetadataModelV0 `tfsdk:"cluster_metadata"`
+	Upgrade           *upgradeMetadata        `tfsdk:"upgrade"`
+}
+
+type clusterMetadataModelV0 struct {
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Database    types.String `tfsdk:"database"`
+	RestoreFrom types.String `tfsdk:"restore_from"`
+	Extensions  types.Set    `tfsdk:"extensions"`
+}
+
+// clusterSchemaV0 is the cluster schema of version 0, only the types of its attributes matter to read a prior state.
+func clusterSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                  schema.StringAttribute{Computed: true},
+			"org_id":              schema.StringAttribute{Computed: true},
+			"name":                schema.StringAttribute{Required: true},
+			"service_type":        schema.StringAttribute{Optional: true, Computed: true},
+			"cloud_provider":      schema.StringAttribute{Required: true},
+			"instance_size":       schema.StringAttribute{Required: true},
+			"region":              schema.StringAttribute{Required: true},
+			"tags":                schema.SetAttribute{Optional: true, ElementType: types.StringType},
+			"network_policy_ids":  schema.SetAttribute{Required: true, ElementType: types.StringType},
+			"dedicated":           schema.BoolAttribute{Optional: true},
+			"shared":              schema.BoolAttribute{Optional: true},
+			"status":              schema.StringAttribute{Computed: true},
+			"data_plane_id":       schema.StringAttribute{Optional: true, Computed: true},
+			"last_updated":        schema.StringAttribute{Computed: true},
+			"created":             schema.StringAttribute{Computed: true},
+			"version":             schema.StringAttribute{Required: true},
+			"storage_policy_name": schema.StringAttribute{Required: true},
+			"metadata": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"cluster_name":      schema.StringAttribute{Computed: true},
+					"manager_uri":       schema.StringAttribute{Computed: true},
+					"connection_uri":    schema.StringAttribute{Computed: true},
+					"metrics_endpoints": schema.SetAttribute{Computed: true, ElementType: types.StringType},
+				},
+			},
+			"cluster_metadata": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"username":     schema.StringAttribute{Required: true},
+					"password":     schema.StringAttribute{Required: true, Sensitive: true},
+					"database":     schema.StringAttribute{Optional: true},
+					"restore_from": schema.StringAttribute{Optional: true},
+					"extensions":   schema.SetAttribute{Optional: true, ElementType: types.StringType},
+				},
+			},
+			"upgrade": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"target_version": schema.StringAttribute{Optional: true},
+					"omit_backup":    schema.BoolAttribute{Optional: true},
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState moves the settings of `cluster_metadata` of a version 0 state into the block of its service type.
+func (r *clusterResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   clusterSchemaV0(),
+			StateUpgrader: upgradeClusterStateV0,
+		},
+	}
+}
+
+func upgradeClusterStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior clusterResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// attributes added since version 0 are left null
+	resp.State.Raw = tftypes.NewValue(resp.State.Schema.Type().TerraformType(ctx), nil)
+	values := map[string]any{
+		"id":                  prior.ID,
+		"org_id":              prior.OrgId,
+		"name":                prior.Name,
+		"service_type":        prior.ServiceType,
+		"cloud_provider":      prior.Provider,
+		"instance_size":       prior.InstanceSize,
+		"region":              prior.Region,
+		"tags":                prior.Tags,
+		"network_policy_ids":  prior.NetworkPolicyIds,
+		"dedicated":           prior.Dedicated,
+		"shared":              prior.Shared,
+		"status":              prior.Status,
+		"data_plane_id":       prior.DataPlaneId,
+		"last_updated":        prior.LastUpdated,
+		"created":             prior.Created,
+		"metadata":            prior.Metadata,
+		"version":             prior.Version,
+		"storage_policy_name": prior.StoragePolicyName,
+		"upgrade":             prior.Upgrade,
+		"cluster_metadata":    prior.ClusterMetadata.upgrade(prior.ServiceType.ValueString()),
+	}
+	for name, value := range values {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+	}
+}
+
+// upgrade returns the settings in the block of the service type. Clusters of other service types than Postgres were
+// created with the same settings, they're kept as far as the block of their service type has them.
+func (m *clusterMetadataModelV0) upgrade(serviceType string) *clusterMetadataModel {
+	if m == nil {
+		return nil
+	}
+	switch serviceType {
+	case service_type.MYSQL:
+		return &clusterMetadataModel{MySql: &mySqlMetadataModel{
+			Username:           m.Username,
+			Password:           m.Password,
+			Database:           m.Database,
+			RestoreFrom:        m.RestoreFrom,
+			RestorePointInTime: types.StringNull(),
+		}}
+	case service_type.REDIS:
+		return &clusterMetadataModel{Redis: &redisMetadataModel{Username: m.Username, Password: m.Password}}
+	case service_type.RABBITMQ:
+		return &clusterMetadataModel{RabbitMq: &rabbitMqMetadataModel{Username: m.Username, Password: m.Password}}
+	}
+	return &clusterMetadataModel{Postgres: &postgresMetadataModel{
+		Username:           m.Username,
+		Password:           m.Password,
+		Database:           m.Database,
+		RestoreFrom:        m.RestoreFrom,
+		RestorePointInTime: types.StringNull(),
+		Extensions:         m.Extensions,
+	}}
+}
@@ -0,0 +1,85 @@
+package mds
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
+	"testing"
+)
+
+func TestUpgradeClusterStateV0(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&clusterResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for _, serviceType := range []string{service_type.POSTGRES, service_type.MYSQL, service_type.REDIS, service_type.RABBITMQ} {
+		t.Run(serviceType, func(t *testing.T) {
+			prior := tfsdk.State{Schema: *clusterSchemaV0(), Raw: tftypes.NewValue(clusterSchemaV0().Type().TerraformType(ctx), nil)}
+			diags := prior.Set(ctx, &clusterResourceModelV0{
+				ID:               types.StringValue("cluster-1"),
+				ServiceType:      types.StringValue(serviceType),
+				Version:          types.StringValue("15.4"),
+				Tags:             types.SetNull(types.StringType),
+				NetworkPolicyIds: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("policy-1")}),
+				Metadata: types.ObjectNull(map[string]attr.Type{
+					"cluster_name": types.StringType, "manager_uri": types.StringType, "connection_uri": types.StringType,
+					"metrics_endpoints": types.SetType{ElemType: types.StringType},
+				}),
+				ClusterMetadata: &clusterMetadataModelV0{
+					Username:    types.StringValue("admin"),
+					Password:    types.StringValue("secret"),
+					Database:    types.StringValue("app"),
+					RestoreFrom: types.StringNull(),
+					Extensions:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("pg_trgm")}),
+				},
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			upgradeClusterStateV0(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var id, version types.String
+			var metadata clusterMetadataModel
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("version"), &version)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("cluster_metadata"), &metadata)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if id.ValueString() != "cluster-1" || version.ValueString() != "15.4" {
+				t.Errorf("expected the other attributes kept, got id %s and version %s", id, version)
+			}
+
+			var username types.String
+			blocks := 0
+			if metadata.Postgres != nil {
+				blocks, username = blocks+1, metadata.Postgres.Username
+				if len(metadata.Postgres.Extensions.Elements()) != 1 {
+					t.Errorf("expected the extensions kept, got %s", metadata.Postgres.Extensions)
+				}
+			}
+			if metadata.MySql != nil {
+				blocks, username = blocks+1, metadata.MySql.Username
+			}
+			if metadata.Redis != nil {
+				blocks, username = blocks+1, metadata.Redis.Username
+			}
+			if metadata.RabbitMq != nil {
+				blocks, username = blocks+1, metadata.RabbitMq.Username
+			}
+			if metadata.request(ctx, serviceType) == nil || blocks != 1 || username.ValueString() != "admin" {
+				t.Errorf("expected the settings moved to the %s block only, got %+v", clusterMetadataBlocks[serviceType], metadata)
+			}
+		})
+	}
+}
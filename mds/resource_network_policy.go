@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/policy_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	customer_metadata "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"regexp"
@@ -151,7 +152,7 @@ func (r *networkPolicyResource) Create(ctx context.Context, req resource.CreateR
 	policyRequest.NetworkSpecs = append(policyRequest.NetworkSpecs, networkSpec)
 
 	tflog.Debug(ctx, "Create Network Policy DTO", map[string]interface{}{"dto": policyRequest})
-	if _, err := r.client.CustomerMetadata.CreatePolicy(&policyRequest); err != nil {
+	if _, err := r.client.CustomerMetadata.CreatePolicy(ctx, &policyRequest); err != nil {
 
 		resp.Diagnostics.AddError(
 			"Submitting request to create Network Policy",
@@ -160,7 +161,7 @@ func (r *networkPolicyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	policies, err := r.client.CustomerMetadata.GetPolicies(&customer_metadata.MdsPoliciesQuery{
+	policies, err := r.client.CustomerMetadata.GetPolicies(ctx, &customer_metadata.MdsPoliciesQuery{
 		Type:  policy_type.NETWORK,
 		Names: []string{plan.Name.ValueString()},
 	})
@@ -219,7 +220,7 @@ func (r *networkPolicyResource) Update(ctx context.Context, req resource.UpdateR
 	tflog.Debug(ctx, "update policy request dto", map[string]interface{}{"dto": updateRequest})
 
 	// Update existing policy
-	if err := r.client.CustomerMetadata.UpdateMdsPolicy(plan.ID.ValueString(), &updateRequest); err != nil {
+	if err := r.client.CustomerMetadata.UpdateMdsPolicy(ctx, plan.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating  Network Policy",
 			"Could not update Network Policy, unexpected error: "+err.Error(),
@@ -227,7 +228,7 @@ func (r *networkPolicyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	policy, err := r.client.CustomerMetadata.GetMDSPolicy(plan.ID.ValueString())
+	policy, err := r.client.CustomerMetadata.GetMDSPolicy(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching Policy",
 			"Could not fetch policy while updating, unexpected error: "+err.Error(),
@@ -260,8 +261,8 @@ func (r *networkPolicyResource) Delete(ctx context.Context, request resource.Del
 	}
 
 	// Submit request to delete MDS Policy
-	err := r.client.CustomerMetadata.DeleteMdsPolicy(state.ID.ValueString())
-	if err != nil {
+	err := r.client.CustomerMetadata.DeleteMdsPolicy(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting MDS Policy",
 			"Could not delete MDS Policy by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -287,7 +288,10 @@ func (r *networkPolicyResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	// Get refreshed policy value from MDS
-	policy, err := r.client.CustomerMetadata.GetMDSPolicy(state.ID.ValueString())
+	policy, err := r.client.CustomerMetadata.GetMDSPolicy(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading Network Policy",
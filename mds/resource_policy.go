@@ -14,6 +14,7 @@ import (
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/policy_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/service_type"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	customer_metadata "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
 	"sort"
@@ -154,7 +155,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 	policyRequest.PermissionsSpec = rolesReq
 
 	tflog.Debug(ctx, "Create Policy DTO", map[string]interface{}{"dto": policyRequest})
-	if _, err := r.client.CustomerMetadata.CreatePolicy(&policyRequest); err != nil {
+	if _, err := r.client.CustomerMetadata.CreatePolicy(ctx, &policyRequest); err != nil {
 
 		resp.Diagnostics.AddError(
 			"Submitting request to create Policy",
@@ -163,7 +164,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	policies, err := r.client.CustomerMetadata.GetPolicies(&customer_metadata.MdsPoliciesQuery{
+	policies, err := r.client.CustomerMetadata.GetPolicies(ctx, &customer_metadata.MdsPoliciesQuery{
 		Type:  plan.ServiceType.ValueString(),
 		Names: []string{plan.Name.ValueString()},
 	})
@@ -227,7 +228,7 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Debug(ctx, "update policy request dto", map[string]interface{}{"dto": updateRequest})
 
 	// Update existing policy
-	if err := r.client.CustomerMetadata.UpdateMdsPolicy(plan.ID.ValueString(), &updateRequest); err != nil {
+	if err := r.client.CustomerMetadata.UpdateMdsPolicy(ctx, plan.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating MDS Policy",
 			"Could not update Policy, unexpected error: "+err.Error(),
@@ -235,7 +236,7 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	policy, err := r.client.CustomerMetadata.GetMDSPolicy(plan.ID.ValueString())
+	policy, err := r.client.CustomerMetadata.GetMDSPolicy(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching Policy",
 			"Could not fetch policy while updating, unexpected error: "+err.Error(),
@@ -268,8 +269,8 @@ func (r *policyResource) Delete(ctx context.Context, request resource.DeleteRequ
 	}
 
 	// Submit request to delete MDS Policy
-	err := r.client.CustomerMetadata.DeleteMdsPolicy(state.ID.ValueString())
-	if err != nil {
+	err := r.client.CustomerMetadata.DeleteMdsPolicy(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting MDS Policy",
 			"Could not delete MDS Policy by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -295,7 +296,10 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Get refreshed policy value from MDS
-	policy, err := r.client.CustomerMetadata.GetMDSPolicy(state.ID.ValueString())
+	policy, err := r.client.CustomerMetadata.GetMDSPolicy(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading MDS Policy",
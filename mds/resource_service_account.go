@@ -2,7 +2,6 @@ package mds
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -258,11 +257,9 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 	plan.Tags.ElementsAs(ctx, &svcAccountRequest.Tags, true)
 	plan.PolicyIds.ElementsAs(ctx, &svcAccountRequest.PolicyIds, true)
 
-	svcAcctCredentials, err := r.client.CustomerMetadata.CreateMdsServiceAccount(&svcAccountRequest)
+	svcAcctCredentials, err := r.client.CustomerMetadata.CreateMdsServiceAccount(ctx, &svcAccountRequest)
 	if err != nil {
-		apiErr := core.ApiError{}
-		errors.As(err, &apiErr)
-		if apiErr.ErrorCode == customer_metadata.DuplicateServiceAccount {
+		if core.HasErrorCode(err, customer_metadata.DuplicateServiceAccount) {
 			resp.Diagnostics.AddError(
 				"Submitting request to create service account",
 				"There was some issue while creating the service account."+
@@ -279,12 +276,12 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	svcAccounts, err := r.client.CustomerMetadata.GetMdsServiceAccounts(&customer_metadata.MdsServiceAccountsQuery{
+	svcAccounts, err := r.client.CustomerMetadata.GetMdsServiceAccounts(ctx, &customer_metadata.MdsServiceAccountsQuery{
 		Names: []string{plan.Name.ValueString()},
 	})
 	createdSvcAcct := &(*svcAccounts.Get())[0]
 
-	svcAccountsOauthApps, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(createdSvcAcct.Id)
+	svcAccountsOauthApps, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(ctx, createdSvcAcct.Id)
 
 	if oauthError != nil {
 		resp.Diagnostics.AddError("Fetching oAuth Apps for the Service Account",
@@ -331,7 +328,7 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 			TTL:         ttl,
 			TimeUnit:    timeunit,
 		}
-		svcAccountsOauthApps, err = r.client.CustomerMetadata.UpdateMDSServiceAccountOauthApp(createdSvcAcct.Id, &updateRequest, svcAccountsOauthApps.AppId)
+		svcAccountsOauthApps, err = r.client.CustomerMetadata.UpdateMDSServiceAccountOauthApp(ctx, createdSvcAcct.Id, &updateRequest, svcAccountsOauthApps.AppId)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Creating MDS service account - Oauth App details",
@@ -373,7 +370,7 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 	state.PolicyIds.ElementsAs(ctx, &updateRequest.PolicyIds, true)
 
 	// Update existing svc account
-	if err := r.client.CustomerMetadata.UpdateMdsServiceAccount(state.ID.ValueString(), &updateRequest); err != nil {
+	if err := r.client.CustomerMetadata.UpdateMdsServiceAccount(ctx, state.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating MDS service account",
 			"Could not update service account, unexpected error: "+err.Error(),
@@ -381,14 +378,14 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	svcAccount, err := r.client.CustomerMetadata.GetMdsServiceAccount(state.ID.ValueString())
+	svcAccount, err := r.client.CustomerMetadata.GetMdsServiceAccount(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching svc account",
 			"Could not fetch svc account while updating, unexpected error: "+err.Error(),
 		)
 		return
 	}
-	svcAccountsOauthAppResponse, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(state.ID.ValueString())
+	svcAccountsOauthAppResponse, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(ctx, state.ID.ValueString())
 	if oauthError != nil {
 		resp.Diagnostics.AddError("Fetching oAuth Apps for the Service Account",
 			"Could not fetch oAuth Apps for the Service Account, unexpected error: "+err.Error(),
@@ -404,7 +401,7 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 			TTL:         serviceAccountOauthApp.TTLSpec.TTL.ValueInt64(),
 			TimeUnit:    serviceAccountOauthApp.TTLSpec.TimeUnit.ValueString(),
 		}
-		oauthApp, err = r.client.CustomerMetadata.UpdateMDSServiceAccountOauthApp(state.ID.ValueString(), &updateRequest, svcAccountsOauthAppResponse.AppId)
+		oauthApp, err = r.client.CustomerMetadata.UpdateMDSServiceAccountOauthApp(ctx, state.ID.ValueString(), &updateRequest, svcAccountsOauthAppResponse.AppId)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Updating MDS service account - Oauth App details",
@@ -448,8 +445,8 @@ func (r *serviceAccountResource) Delete(ctx context.Context, request resource.De
 	}
 
 	// Submit request to delete MDS Cluster
-	err := r.client.CustomerMetadata.DeleteMdsServiceAccount(state.ID.ValueString())
-	if err != nil {
+	err := r.client.CustomerMetadata.DeleteMdsServiceAccount(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting MDS svc account",
 			"Could not delete MDS svc account by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -475,12 +472,8 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	// Get refreshed service account value from MDS
-	svcAcct, err := r.client.CustomerMetadata.GetMdsServiceAccount(state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Updating MDS service account",
-			"Could not update service account, unexpected error: "+err.Error(),
-		)
+	svcAcct, err := r.client.CustomerMetadata.GetMdsServiceAccount(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
 		return
 	}
 	if err != nil {
@@ -491,7 +484,7 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	svcAccountsOauthApps, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(state.ID.ValueString())
+	svcAccountsOauthApps, oauthError := r.client.CustomerMetadata.GetMDSServiceAccountOauthApp(ctx, state.ID.ValueString())
 
 	if oauthError != nil {
 		resp.Diagnostics.AddError("Fetching oAuth Apps for the Service Account",
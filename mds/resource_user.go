@@ -14,8 +14,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds/core"
 	customer_metadata "github.com/svc-bot-mds/terraform-provider-vmds/client/mds/customer-metadata"
 	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"time"
+)
+
+const (
+	userInvitationTimeout = 5 * time.Minute
+	// userListed is the state of an invited user once it can be found
+	userListed = "LISTED"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -187,7 +195,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 	plan.Tags.ElementsAs(ctx, &userRequest.Tags, true)
 	plan.PolicyIds.ElementsAs(ctx, &userRequest.PolicyIds, true)
-	if err := r.client.CustomerMetadata.CreateMdsUser(&userRequest); err != nil {
+	if err := r.client.CustomerMetadata.CreateMdsUser(ctx, &userRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Submitting request to create User",
 			"Could not create User, unexpected error: "+err.Error(),
@@ -195,33 +203,31 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	users, err := r.client.CustomerMetadata.GetMdsUsers(&customer_metadata.MdsUsersQuery{
-		Emails: []string{plan.Email.ValueString()},
-	})
-
-	if err != nil {
-		resp.Diagnostics.AddError("Fetching user",
-			"Could not fetch users, unexpected error: "+err.Error(),
-		)
-		return
+	// invited user shows up in the list once MDS processed the invitation
+	waiter := core.StateChangeConf[model.MdsUser]{
+		Target: []string{userListed},
+		Refresh: func(ctx context.Context) (*model.MdsUser, string, error) {
+			users, err := r.client.CustomerMetadata.GetMdsUsers(ctx, &customer_metadata.MdsUsersQuery{
+				Emails: []string{plan.Email.ValueString()},
+			})
+			if err != nil || len(*users.Get()) == 0 {
+				return nil, "", err
+			}
+			return &(*users.Get())[0], userListed, nil
+		},
+		Timeout:         userInvitationTimeout,
+		MinPollInterval: 2 * time.Second,
+		MaxPollInterval: 10 * time.Second,
+		NotFoundChecks:  5,
 	}
-	if users.Page.TotalElements == 0 {
+	createdUser, err := waiter.WaitForState(ctx)
+	if err != nil {
 		resp.Diagnostics.AddError("Fetching user",
-			fmt.Sprintf("Could not find any user by email [%s], server error must have occurred while creating user.", plan.Email.ValueString()),
+			fmt.Sprintf("Could not find any user by email [%s], server error must have occurred while creating user: %s", plan.Email.ValueString(), err.Error()),
 		)
 		return
 	}
 
-	if len(*users.Get()) <= 0 {
-		resp.Diagnostics.AddError("Fetching User",
-			"Unable to fetch the created user",
-		)
-		return
-	}
-
-	// Map response body to schema and populate Computed attribute values
-	createdUser := &(*users.Get())[0]
-
 	if saveFromUserResponse(&ctx, &resp.Diagnostics, &plan, createdUser) != 0 {
 		return
 	}
@@ -267,7 +273,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Update existing user
-	if err := r.client.CustomerMetadata.UpdateMdsUser(plan.ID.ValueString(), &updateRequest); err != nil {
+	if err := r.client.CustomerMetadata.UpdateMdsUser(ctx, plan.ID.ValueString(), &updateRequest); err != nil {
 		resp.Diagnostics.AddError(
 			"Updating MDS User",
 			"Could not update user, unexpected error: "+err.Error(),
@@ -275,7 +281,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	user, err := r.client.CustomerMetadata.GetMdsUser(plan.ID.ValueString())
+	user, err := r.client.CustomerMetadata.GetMdsUser(ctx, plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Fetching User",
 			"Could not fetch users while updating, unexpected error: "+err.Error(),
@@ -308,8 +314,8 @@ func (r *userResource) Delete(ctx context.Context, request resource.DeleteReques
 	}
 
 	// Submit request to delete MDS Cluster
-	err := r.client.CustomerMetadata.DeleteMdsUser(state.ID.ValueString())
-	if err != nil {
+	err := r.client.CustomerMetadata.DeleteMdsUser(ctx, state.ID.ValueString())
+	if err != nil && !core.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Deleting MDS User",
 			"Could not delete MDS User by ID "+state.ID.ValueString()+": "+err.Error(),
@@ -335,7 +341,10 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed cluster value from MDS
-	user, err := r.client.CustomerMetadata.GetMdsUser(state.ID.ValueString())
+	user, err := r.client.CustomerMetadata.GetMdsUser(ctx, state.ID.ValueString())
+	if removeIfNotFound(ctx, err, &resp.State, state.ID.ValueString()) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Reading MDS user",
@@ -0,0 +1,168 @@
+package mds
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// serviceVersionPattern matches a semantic version, optionally prefixed by the service, e.g. `15.4`, `v3.11.2` or `postgres-14`.
+var serviceVersionPattern = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9]*-)?v?([0-9]+)(?:\.([0-9]+))?(?:\.([0-9]+))?(?:-([0-9A-Za-z.-]+))?$`)
+
+// versionPrefixPattern matches the leading numbers of a version, e.g. `15` or `15.4`.
+var versionPrefixPattern = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+){0,2}$`)
+
+// serviceVersion is a version of a service in semver order, missing minor and patch numbers are 0.
+type serviceVersion struct {
+	numbers [3]int
+	// parts is the count of numbers given, e.g. 2 for `15.4`
+	parts      int
+	prerelease string
+}
+
+func parseServiceVersion(version string) (serviceVersion, error) {
+	match := serviceVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return serviceVersion{}, fmt.Errorf("%q is not a semantic version, e.g. 15.4 or 3.11.2", version)
+	}
+	parsed := serviceVersion{prerelease: match[4]}
+	for i, number := range match[1:4] {
+		if number == "" {
+			continue
+		}
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			return serviceVersion{}, fmt.Errorf("%q is not a semantic version: %w", version, err)
+		}
+		parsed.numbers[i] = value
+		parsed.parts++
+	}
+	return parsed, nil
+}
+
+// compare returns -1, 0 or 1 as v is older than, same as or newer than other. A pre-release is older than its release.
+func (v serviceVersion) compare(other serviceVersion) int {
+	for i := range v.numbers {
+		if v.numbers[i] < other.numbers[i] {
+			return -1
+		}
+		if v.numbers[i] > other.numbers[i] {
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	case v.prerelease < other.prerelease:
+		return -1
+	}
+	return 1
+}
+
+// compareVersions returns -1, 0 or 1 as version a is older than, same as or newer than b, e.g. `postgres-14` is older
+// than `15.2`. Versions which are not semantic compare as same.
+func compareVersions(a, b string) int {
+	aVersion, aErr := parseServiceVersion(a)
+	bVersion, bErr := parseServiceVersion(b)
+	if aErr != nil || bErr != nil {
+		return 0
+	}
+	return aVersion.compare(bVersion)
+}
+
+// validateUpgrade returns why the cluster cannot be upgraded from the current version to the target one,
+// nil when it's a legal upgrade path.
+func validateUpgrade(current string, target string) error {
+	targetVersion, err := parseServiceVersion(target)
+	if err != nil {
+		return err
+	}
+	currentVersion, err := parseServiceVersion(current)
+	if err != nil {
+		// an unknown scheme is left to MDS to order
+		return nil
+	}
+	if targetVersion.compare(currentVersion) <= 0 {
+		return fmt.Errorf("version %s is not newer than the current version %s, clusters can only be upgraded", target, current)
+	}
+	return nil
+}
+
+// versionHasPrefix tells whether the numbers of the version start with the ones of the prefix, e.g. `15.4.2` has
+// the prefix `15` and `15.4` but not `15.3`.
+func versionHasPrefix(version string, prefix string) bool {
+	parsedPrefix, err := parseServiceVersion(prefix)
+	if err != nil {
+		return false
+	}
+	parsedVersion, err := parseServiceVersion(version)
+	if err != nil || parsedVersion.parts < parsedPrefix.parts {
+		return false
+	}
+	for i := 0; i < parsedPrefix.parts; i++ {
+		if parsedVersion.numbers[i] != parsedPrefix.numbers[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameVersion tells whether the configured version designates the actual one, either spelled the same or by its
+// leading numbers, e.g. `15` and `v15.4` both designate `15.4.2`.
+func sameVersion(configured string, actual string) bool {
+	if configured == actual {
+		return true
+	}
+	configuredVersion, err := parseServiceVersion(configured)
+	if err != nil {
+		return false
+	}
+	actualVersion, err := parseServiceVersion(actual)
+	if err != nil {
+		return false
+	}
+	// a pre-release is designated by its full version only
+	if configuredVersion.prerelease != "" {
+		return configuredVersion.parts == actualVersion.parts && configuredVersion.compare(actualVersion) == 0
+	}
+	return versionHasPrefix(actual, configured)
+}
+
+// latestVersion returns the newest of the semantic versions which aren't pre-releases, empty when there's none.
+func latestVersion(versions []string) string {
+	var latest string
+	var latestParsed serviceVersion
+	for _, version := range versions {
+		parsed, err := parseServiceVersion(version)
+		if err != nil || parsed.prerelease != "" {
+			continue
+		}
+		if latest == "" || parsed.compare(latestParsed) > 0 {
+			latest, latestParsed = version, parsed
+		}
+	}
+	return latest
+}
+
+// resolveVersion returns the offered version the configured one designates, the newest when its leading numbers
+// designate several, e.g. `15` resolves to `15.4` among `14.9`, `15.2` and `15.4`. The configured version is
+// returned as is when it designates none of them.
+func resolveVersion(configured string, offered []string) string {
+	var designated []string
+	for _, version := range offered {
+		if version == configured {
+			return version
+		}
+		if sameVersion(configured, version) {
+			designated = append(designated, version)
+		}
+	}
+	if latest := latestVersion(designated); latest != "" {
+		return latest
+	}
+	return configured
+}
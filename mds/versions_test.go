@@ -0,0 +1,98 @@
+package mds
+
+import "testing"
+
+func TestSameVersion(t *testing.T) {
+	tests := map[string]struct {
+		configured string
+		actual     string
+		want       bool
+	}{
+		"equal":                    {configured: "15.4", actual: "15.4", want: true},
+		"major of actual":          {configured: "15", actual: "15.4", want: true},
+		"major and minor":          {configured: "15.4", actual: "15.4.2", want: true},
+		"prefixed by v":            {configured: "v15.4", actual: "15.4.2", want: true},
+		"prefixed by service":      {configured: "15", actual: "postgres-15.4", want: true},
+		"other minor":              {configured: "15.3", actual: "15.4", want: false},
+		"other major":              {configured: "14", actual: "15.4", want: false},
+		"longer than actual":       {configured: "15.4.2", actual: "15.4", want: false},
+		"pre-release of actual":    {configured: "15.4.2-rc1", actual: "15.4.2", want: false},
+		"same pre-release":         {configured: "v15.4.2-rc1", actual: "15.4.2-rc1", want: true},
+		"not semantic":             {configured: "latest", actual: "15.4", want: false},
+		"not semantic but same":    {configured: "latest", actual: "latest", want: true},
+		"empty configured version": {configured: "", actual: "15.4", want: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := sameVersion(test.configured, test.actual); got != test.want {
+				t.Errorf("expected %t for %q and %q, got %t", test.want, test.configured, test.actual, got)
+			}
+		})
+	}
+}
+
+func TestValidateUpgrade(t *testing.T) {
+	tests := map[string]struct {
+		current string
+		target  string
+		wantErr bool
+	}{
+		"newer patch":         {current: "15.4.1", target: "15.4.2"},
+		"newer major":         {current: "postgres-14", target: "15.2"},
+		"release of pre":      {current: "15.4.2-rc1", target: "15.4.2"},
+		"same":                {current: "15.4", target: "15.4", wantErr: true},
+		"older":               {current: "15.4", target: "15.3.9", wantErr: true},
+		"current not semver":  {current: "latest", target: "15.4"},
+		"target not semver":   {current: "15.4", target: "latest", wantErr: true},
+		"pre-release of same": {current: "15.4.2", target: "15.4.2-rc1", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := validateUpgrade(test.current, test.target); (err != nil) != test.wantErr {
+				t.Errorf("expected error %t upgrading %s to %s, got: %v", test.wantErr, test.current, test.target, err)
+			}
+		})
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	tests := map[string]struct {
+		versions []string
+		want     string
+	}{
+		"none":                {versions: nil, want: ""},
+		"newest":              {versions: []string{"15.2", "15.10.1", "15.4"}, want: "15.10.1"},
+		"pre-release skipped": {versions: []string{"15.4", "16.0-beta1"}, want: "15.4"},
+		"not semver skipped":  {versions: []string{"latest", "14"}, want: "14"},
+		"only pre-releases":   {versions: []string{"16.0-beta1"}, want: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := latestVersion(test.versions); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	offered := []string{"14.9", "15.2", "15.4", "16.0-beta1"}
+	tests := map[string]struct {
+		configured string
+		want       string
+	}{
+		"offered":                 {configured: "15.2", want: "15.2"},
+		"major":                   {configured: "15", want: "15.4"},
+		"prefixed":                {configured: "v15.2", want: "15.2"},
+		"pre-release by its name": {configured: "16.0-beta1", want: "16.0-beta1"},
+		"only a pre-release":      {configured: "16", want: "16"},
+		"not offered":             {configured: "17", want: "17"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := resolveVersion(test.configured, offered); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
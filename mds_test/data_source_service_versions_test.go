@@ -0,0 +1,40 @@
+package mds_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/svc-bot-mds/terraform-provider-vmds/constants/common"
+	"testing"
+)
+
+func TestMdsServiceVersionsDataSource(t *testing.T) {
+	api := newFakeApi(t)
+	api.put("/api/controller/mdsservices/versions", map[string]interface{}{
+		"versions": []map[string]interface{}{
+			{"serviceType": "POSTGRES", "version": "14.9.0", "status": "ACTIVE"},
+			{"serviceType": "POSTGRES", "version": "15.4.0", "status": "ACTIVE"},
+			{"serviceType": "POSTGRES", "version": "15.4.2", "status": "ACTIVE", "isDefault": true},
+			{"serviceType": "POSTGRES", "version": "15.5.0-rc1", "status": "PREVIEW"},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: api.providerConfig() + `data "vmds_service_versions" "postgres" {
+  service_type   = "POSTGRES"
+  version_prefix = "15"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vmds_service_versions.postgres", "versions.#", "3"),
+					resource.TestCheckResourceAttr("data.vmds_service_versions.postgres", "versions.1.default", "true"),
+					// pre-releases are listed but never the latest
+					resource.TestCheckResourceAttr("data.vmds_service_versions.postgres", "latest_version", "15.4.2"),
+					resource.TestCheckResourceAttr("data.vmds_service_versions.postgres", "id", common.DataSource+common.ServiceVersionsId),
+				),
+			},
+		},
+	})
+}
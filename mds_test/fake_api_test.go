@@ -0,0 +1,188 @@
+package mds_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/constants/oauth_type"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/mds"
+	"github.com/svc-bot-mds/terraform-provider-vmds/client/model"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeApi stands in for MDS in tests which run the provider without a live MDS. Besides the token endpoint,
+// it keeps the objects posted to a collection in memory, under the collection path followed by their ID.
+type fakeApi struct {
+	*httptest.Server
+	lock    sync.Mutex
+	objects map[string]map[string]interface{}
+	created int
+	deletes int
+	// requests are the method and path of every request received, besides the token requests
+	requests []string
+	// deletesNotFound makes deletions answer 404 while the objects are still readable,
+	// as when an object is being deleted by someone else.
+	deletesNotFound bool
+	// handlers answer the requests of their method and path instead of the in-memory objects
+	handlers map[string]fakeHandler
+}
+
+// fakeHandler answers a request with a status and a body, given the request and its decoded body. It's called with
+// the lock of the fake API held, so it reads and changes its objects directly.
+type fakeHandler func(r *http.Request, body map[string]interface{}) (int, interface{})
+
+func newFakeApi(t *testing.T) *fakeApi {
+	t.Helper()
+	api := &fakeApi{objects: map[string]map[string]interface{}{}, handlers: map[string]fakeHandler{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(api.Close)
+	return api
+}
+
+// providerConfig configures the provider against the fake API.
+func (api *fakeApi) providerConfig() string {
+	return fmt.Sprintf(`
+provider "vmds" {
+   host      = %q
+   api_token = "API_TOKEN"
+}
+`, api.URL)
+}
+
+// put stores the object at urlPath, as if it was created outside of Terraform.
+func (api *fakeApi) put(urlPath string, object map[string]interface{}) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	api.objects[urlPath] = object
+}
+
+// remove deletes the object at urlPath, as if it was deleted outside of Terraform.
+func (api *fakeApi) remove(urlPath string) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	delete(api.objects, urlPath)
+}
+
+// handle answers the requests of the method for urlPath with the handler, e.g. to start a task.
+func (api *fakeApi) handle(method string, urlPath string, handler fakeHandler) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	api.handlers[method+" "+urlPath] = handler
+}
+
+// task stores a task of MDS in the status, which ran on the resource of the ID, and returns the response starting it.
+// It's meant for handlers, which already hold the lock.
+func (api *fakeApi) task(status string, resourceId string) map[string]interface{} {
+	api.created++
+	id := fmt.Sprintf("task-%d", api.created)
+	api.objects["/api/taskservice/tasks/"+id] = map[string]interface{}{"id": id, "status": status, "resourceId": resourceId}
+	return map[string]interface{}{"taskId": id}
+}
+
+func (api *fakeApi) setDeletesNotFound(notFound bool) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	api.deletesNotFound = notFound
+}
+
+func (api *fakeApi) deleteCount() int {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	return api.deletes
+}
+
+// requested tells whether a request was received with the method for urlPath.
+func (api *fakeApi) requested(method string, urlPath string) bool {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	for _, request := range api.requests {
+		if request == method+" "+urlPath {
+			return true
+		}
+	}
+	return false
+}
+
+// client returns an MDS client of the fake API, for tests calling the resources directly.
+func (api *fakeApi) client(t *testing.T) *mds.Client {
+	t.Helper()
+	client, err := mds.NewClient(&api.URL, &model.ClientAuth{ApiToken: "API_TOKEN", OAuthAppType: oauth_type.ApiToken})
+	if err != nil {
+		t.Fatalf("unable to create the client: %v", err)
+	}
+	client.TaskService.PollInterval = time.Millisecond
+	return client
+}
+
+func (api *fakeApi) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Path == "/api/authservice/token" {
+		_, _ = w.Write([]byte(fakeToken()))
+		return
+	}
+	api.requests = append(api.requests, r.Method+" "+r.URL.Path)
+	if handler, ok := api.handlers[r.Method+" "+r.URL.Path]; ok {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		status, response := handler(r, body)
+		writeJSON(w, status, response)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		var object map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"errorCode": "BAD_REQUEST", "errorMsg": err.Error()})
+			return
+		}
+		api.created++
+		object["id"] = fmt.Sprintf("%s-%d", path.Base(r.URL.Path), api.created)
+		api.objects[r.URL.Path+"/"+object["id"].(string)] = object
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodGet:
+		object, ok := api.objects[r.URL.Path]
+		if !ok {
+			writeNotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodDelete:
+		api.deletes++
+		if _, ok := api.objects[r.URL.Path]; !ok || api.deletesNotFound {
+			writeNotFound(w, r)
+			return
+		}
+		delete(api.objects, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusNotFound, map[string]string{
+		"errorCode": "NOT_FOUND",
+		"errorMsg":  fmt.Sprintf("%s not found", r.URL.Path),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// fakeToken is an unsigned access token, the provider only reads its claims.
+func fakeToken() string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := encode([]byte(fmt.Sprintf(`{"context_name":"test-org","exp":%d}`, time.Now().Add(time.Hour).Unix())))
+	return header + "." + claims + "." + encode([]byte("signature"))
+}
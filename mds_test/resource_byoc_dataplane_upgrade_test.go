@@ -0,0 +1,129 @@
+package mds_test
+
+import (
+	"context"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"testing"
+)
+
+// dataPlaneAttributes are the attributes of dataplane-1 in state and plan, besides its version.
+func dataPlaneAttributes(version string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":             "dataplane-1",
+		"name":           "test-dataplane",
+		"account_id":     "account-1",
+		"certificate_id": "certificate-1",
+		"nodepool_type":  "regular",
+		"region":         "eu-west-1",
+		"version":        version,
+	}
+}
+
+// putDataPlane stores a ready dataplane as MDS returns it.
+func putDataPlane(api *fakeApi, id string, version string) {
+	api.put("/api/infra-connector/k8s-cluster/"+id, map[string]interface{}{
+		"id":           id,
+		"name":         "test-dataplane",
+		"provider":     "aws",
+		"region":       "eu-west-1",
+		"version":      version,
+		"status":       "READY",
+		"nodePoolType": "regular",
+		"certificate":  map[string]string{"name": "test-certificate", "domainName": "example.com"},
+	})
+}
+
+func TestDataPlaneUpgradeResolvesVersion(t *testing.T) {
+	tests := map[string]struct {
+		version        string
+		targetVersions []string
+		wantSubmitted  string
+	}{
+		"offered":        {version: "1.29.4", targetVersions: []string{"1.29.4", "1.30.1"}, wantSubmitted: "1.29.4"},
+		"minor":          {version: "1.29", targetVersions: []string{"1.29.2", "1.29.4", "1.30.1"}, wantSubmitted: "1.29.4"},
+		"targets failed": {version: "1.29", wantSubmitted: "1.29"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			putDataPlane(api, "dataplane-1", "1.28.5")
+			submitted := handleUpgrades(api, test.targetVersions)
+			r := configuredResource(t, api, "vmds_byoc_dataplane")
+			state := tfsdk.State(planOf(t, r, dataPlaneAttributes("1.28")))
+			plan := planOf(t, r, dataPlaneAttributes(test.version))
+
+			resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.Update(context.Background(), fwresource.UpdateRequest{State: state, Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if submitted["dataplane-1"] != test.wantSubmitted {
+				t.Errorf("expected the version %s submitted, got %q", test.wantSubmitted, submitted["dataplane-1"])
+			}
+			// the version is kept as configured, so the next plan is empty
+			if version := stringAttribute(t, resp.State, "version"); version != test.version {
+				t.Errorf("expected the version %s saved, got %s", test.version, version)
+			}
+		})
+	}
+}
+
+func TestDataPlaneVersionValidation(t *testing.T) {
+	tests := map[string]struct {
+		version     string
+		wantErr     bool
+		wantFetches int
+	}{
+		"run by a dataplane":   {version: "1.28"},
+		"offered to a version": {version: "1.29.6", wantFetches: 1},
+		"offered to the other": {version: "1.30", wantFetches: 2},
+		"not offered":          {version: "1.31", wantErr: true, wantFetches: 2},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			// two dataplanes run the same version, they're offered the same upgrade targets
+			putDataPlane(api, "dataplane-1", "1.28.5")
+			putDataPlane(api, "dataplane-2", "1.28.5")
+			putDataPlane(api, "dataplane-3", "1.29.2")
+			api.handle(http.MethodGet, "/api/infra-connector/k8s-cluster", func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, map[string]interface{}{
+					"_embedded": map[string]interface{}{"k8sClusterDTOes": []interface{}{
+						api.objects["/api/infra-connector/k8s-cluster/dataplane-1"],
+						api.objects["/api/infra-connector/k8s-cluster/dataplane-2"],
+						api.objects["/api/infra-connector/k8s-cluster/dataplane-3"],
+					}},
+					"page": map[string]int{"number": 0, "size": 100, "totalElements": 3, "totalPages": 1},
+				}
+			})
+			fetches := 0
+			api.handle(http.MethodGet, upgradeTargetsPath, func(r *http.Request, _ map[string]interface{}) (int, interface{}) {
+				fetches++
+				targetVersions := map[string][]string{"1.28.5": {"1.29.6"}, "1.29.2": {"1.30.1"}}
+				id := r.URL.Query().Get("id")
+				version := api.objects["/api/infra-connector/k8s-cluster/"+id]["version"].(string)
+				return http.StatusOK, map[string]interface{}{"id": id, "targetVersions": targetVersions[version]}
+			})
+			r := configuredResource(t, api, "vmds_byoc_dataplane")
+			attributes := dataPlaneAttributes(test.version)
+			delete(attributes, "id")
+			plan := planOf(t, r, attributes)
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				State:  tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)},
+				Plan:   plan,
+				Config: tfsdk.Config(plan),
+			}, &resp)
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("expected error %t, got: %v", test.wantErr, resp.Diagnostics)
+			}
+			if fetches != test.wantFetches {
+				t.Errorf("expected the upgrade targets fetched %d times, got %d", test.wantFetches, fetches)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"testing"
+)
+
+const clusterBackupPolicyPath = "/api/controller/mdsclusters/cluster-1/backup-policy"
+
+// backupPolicyAttributes are the attributes of the backup policy of cluster-1 taken every night.
+func backupPolicyAttributes() map[string]interface{} {
+	return map[string]interface{}{
+		"id":              "cluster-1",
+		"cluster_id":      "cluster-1",
+		"cron_schedule":   "0 2 * * *",
+		"retention_count": 7,
+		"retention_days":  30,
+		"pitr_enabled":    false,
+	}
+}
+
+func TestClusterBackupPolicyConfigValidators(t *testing.T) {
+	tests := map[string]struct {
+		cronSchedule string
+		dailyWindow  string
+		wantErr      bool
+	}{
+		"cron schedule": {cronSchedule: "0 2 * * *"},
+		"daily window":  {dailyWindow: "02:00"},
+		"both":          {cronSchedule: "0 2 * * *", dailyWindow: "02:00", wantErr: true},
+		"neither":       {wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := configuredResource(t, newFakeApi(t), "vmds_cluster_backup_policy")
+			attributes := map[string]interface{}{"cluster_id": "cluster-1", "retention_count": 7, "retention_days": 30}
+			if test.cronSchedule != "" {
+				attributes["cron_schedule"] = test.cronSchedule
+			}
+			if test.dailyWindow != "" {
+				attributes["daily_window"] = test.dailyWindow
+			}
+			config := tfsdk.Config(planOf(t, r, attributes))
+
+			var resp fwresource.ValidateConfigResponse
+			for _, validator := range r.(fwresource.ResourceWithConfigValidators).ConfigValidators(context.Background()) {
+				validator.ValidateResource(context.Background(), fwresource.ValidateConfigRequest{Config: config}, &resp)
+			}
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("expected error %t, got: %v", test.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestClusterBackupPolicyCreate(t *testing.T) {
+	api := newFakeApi(t)
+	var putBody map[string]interface{}
+	api.handle(http.MethodPut, clusterBackupPolicyPath, func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+		putBody = body
+		body["clusterId"] = "cluster-1"
+		api.objects[clusterBackupPolicyPath] = body
+		return http.StatusOK, body
+	})
+	r := configuredResource(t, api, "vmds_cluster_backup_policy")
+	attributes := backupPolicyAttributes()
+	delete(attributes, "id")
+	plan := planOf(t, r, attributes)
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if putBody["cronSchedule"] != "0 2 * * *" || putBody["retentionCount"] != float64(7) || putBody["retentionDays"] != float64(30) {
+		t.Errorf("expected the configured policy submitted, got %v", putBody)
+	}
+	if id := createdId(t, resp.State); id != "cluster-1" {
+		t.Errorf("expected the policy saved by the ID of its cluster, got %q", id)
+	}
+	if dailyWindow := stringAttribute(t, resp.State, "daily_window"); dailyWindow != "" {
+		t.Errorf("expected no daily window, got %s", dailyWindow)
+	}
+}
+
+func TestClusterBackupPolicyRead(t *testing.T) {
+	api := newFakeApi(t)
+	// changed in the MDS console to a daily window
+	api.put(clusterBackupPolicyPath, map[string]interface{}{
+		"clusterId":      "cluster-1",
+		"dailyWindow":    "03:30",
+		"retentionCount": 14,
+		"retentionDays":  30,
+		"pitrEnabled":    true,
+	})
+	r := configuredResource(t, api, "vmds_cluster_backup_policy")
+	state := tfsdk.State(planOf(t, r, backupPolicyAttributes()))
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if cronSchedule := stringAttribute(t, resp.State, "cron_schedule"); cronSchedule != "" {
+		t.Errorf("expected the cron schedule dropped, got %s", cronSchedule)
+	}
+	if dailyWindow := stringAttribute(t, resp.State, "daily_window"); dailyWindow != "03:30" {
+		t.Errorf("expected the daily window refreshed, got %s", dailyWindow)
+	}
+	var retentionCount types.Int64
+	var pitrEnabled types.Bool
+	resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("retention_count"), &retentionCount)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("pitr_enabled"), &pitrEnabled)...)
+	if retentionCount.ValueInt64() != 14 || !pitrEnabled.ValueBool() {
+		t.Errorf("expected the retention and PITR refreshed, got %s and %s", retentionCount, pitrEnabled)
+	}
+
+	// the cluster was deleted with its policy: the refresh drops it from state
+	api.remove(clusterBackupPolicyPath)
+	resp = fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the policy removed from state")
+	}
+}
+
+func TestClusterBackupPolicyDelete(t *testing.T) {
+	for name, deleted := range map[string]bool{"reset": false, "cluster deleted": true} {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			if !deleted {
+				api.put(clusterBackupPolicyPath, map[string]interface{}{"clusterId": "cluster-1", "cronSchedule": "0 2 * * *"})
+			}
+			r := configuredResource(t, api, "vmds_cluster_backup_policy")
+			state := tfsdk.State(planOf(t, r, backupPolicyAttributes()))
+
+			var resp fwresource.DeleteResponse
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if !api.requested(http.MethodDelete, clusterBackupPolicyPath) {
+				t.Errorf("expected the policy reset at %s", clusterBackupPolicyPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/svc-bot-mds/terraform-provider-vmds/mds"
+	"net/http"
+	"testing"
+)
+
+const clusterBackupsPath = "/api/controller/mdsclusters/cluster-1/backups"
+
+func backupObject(id string, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"name":        "nightly",
+		"clusterId":   "cluster-1",
+		"clusterName": "test-cluster",
+		"serviceType": "POSTGRES",
+		"version":     "15.4",
+		"backupType":  "ON_DEMAND",
+		"size":        "2 GB",
+		"status":      status,
+		"timeCreated": "2025-01-01T00:00:00Z",
+	}
+}
+
+// stringAttribute returns the value of the attribute in state, empty when the state is null.
+func stringAttribute(t *testing.T, state tfsdk.State, name string) string {
+	t.Helper()
+	if state.Raw.IsNull() {
+		return ""
+	}
+	var value types.String
+	if diags := state.GetAttribute(context.Background(), path.Root(name), &value); diags.HasError() {
+		t.Fatalf("unable to read %s: %v", name, diags)
+	}
+	return value.ValueString()
+}
+
+func TestClusterBackupCreate(t *testing.T) {
+	tests := map[string]struct {
+		taskStatus string
+		// backupStatus is the status of the backup taken, none is taken when empty
+		backupStatus string
+		// reportId tells whether the task reports the ID of the backup
+		reportId   bool
+		wantId     string
+		wantStatus string
+		wantErr    bool
+	}{
+		"completed":                  {taskStatus: "SUCCESS", backupStatus: "COMPLETED", reportId: true, wantId: "backup-1", wantStatus: "COMPLETED"},
+		"found by name":              {taskStatus: "SUCCESS", backupStatus: "COMPLETED", wantId: "backup-1", wantStatus: "COMPLETED"},
+		"backup failed":              {taskStatus: "SUCCESS", backupStatus: "FAILED", reportId: true, wantId: "backup-1", wantStatus: "FAILED", wantErr: true},
+		"task failed after backup":   {taskStatus: "FAILED", backupStatus: "IN_PROGRESS", reportId: true, wantId: "backup-1", wantErr: true},
+		"task failed without backup": {taskStatus: "FAILED", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			api.handle(http.MethodPost, clusterBackupsPath, func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+				if body["name"] != "nightly" {
+					return http.StatusBadRequest, map[string]string{"errorMsg": "unexpected name"}
+				}
+				if test.backupStatus == "" {
+					return http.StatusOK, api.task(test.taskStatus, "")
+				}
+				api.objects[clusterBackupsPath+"/backup-1"] = backupObject("backup-1", test.backupStatus)
+				if !test.reportId {
+					return http.StatusOK, api.task(test.taskStatus, "")
+				}
+				return http.StatusOK, api.task(test.taskStatus, "backup-1")
+			})
+			api.handle(http.MethodGet, clusterBackupsPath, func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, map[string]interface{}{
+					"_embedded": map[string]interface{}{"mdsBackupDTOes": []interface{}{api.objects[clusterBackupsPath+"/backup-1"]}},
+					"page":      map[string]int{"number": 0, "size": 100, "totalElements": 1, "totalPages": 1},
+				}
+			})
+			r := configuredResource(t, api, "vmds_cluster_backup")
+			plan := planOf(t, r, map[string]interface{}{"cluster_id": "cluster-1", "name": "nightly"})
+
+			resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Fatalf("expected error %t, got: %v", test.wantErr, resp.Diagnostics)
+			}
+			// a backup kept in state despite an error is tainted by Terraform, and replaced on the next apply
+			if id := stringAttribute(t, resp.State, "id"); id != test.wantId {
+				t.Errorf("expected the ID %q saved in state, got %q", test.wantId, id)
+			}
+			if status := stringAttribute(t, resp.State, "status"); status != test.wantStatus {
+				t.Errorf("expected the status %q saved in state, got %q", test.wantStatus, status)
+			}
+			if test.wantId != "" && stringAttribute(t, resp.State, "cluster_id") != "cluster-1" {
+				t.Errorf("expected the cluster ID saved in state")
+			}
+		})
+	}
+}
+
+func TestClusterBackupReadAndDelete(t *testing.T) {
+	api := newFakeApi(t)
+	api.put(clusterBackupsPath+"/backup-1", backupObject("backup-1", "COMPLETED"))
+	api.handle(http.MethodDelete, clusterBackupsPath+"/backup-1", func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+		delete(api.objects, clusterBackupsPath+"/backup-1")
+		return http.StatusOK, api.task("SUCCESS", "backup-1")
+	})
+	r := configuredResource(t, api, "vmds_cluster_backup")
+	state := tfsdk.State(planOf(t, r, map[string]interface{}{"id": "backup-1", "cluster_id": "cluster-1", "status": "IN_PROGRESS"}))
+
+	// the refresh picks the changes made in MDS
+	readResp := fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	if status := stringAttribute(t, readResp.State, "status"); status != "COMPLETED" {
+		t.Errorf("expected the refreshed status COMPLETED, got %q", status)
+	}
+	if size := stringAttribute(t, readResp.State, "size"); size != "2 GB" {
+		t.Errorf("expected the refreshed size 2 GB, got %q", size)
+	}
+
+	var deleteResp fwresource.DeleteResponse
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: readResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", deleteResp.Diagnostics)
+	}
+	if !api.requested(http.MethodDelete, clusterBackupsPath+"/backup-1") {
+		t.Errorf("expected the backup deleted")
+	}
+
+	// deleted since: the refresh drops it from state
+	readResp = fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() || !readResp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted backup removed from state, got: %v", readResp.Diagnostics)
+	}
+}
+
+func TestClusterBackupImportState(t *testing.T) {
+	tests := map[string]struct {
+		importId      string
+		wantClusterId string
+		wantId        string
+	}{
+		"cluster and backup": {importId: "cluster-1/backup-1", wantClusterId: "cluster-1", wantId: "backup-1"},
+		"backup only":        {importId: "backup-1"},
+		"no cluster":         {importId: "/backup-1"},
+		"no backup":          {importId: "cluster-1/"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := configuredResource(t, newFakeApi(t), "vmds_cluster_backup")
+			var schema fwresource.SchemaResponse
+			r.Schema(context.Background(), fwresource.SchemaRequest{}, &schema)
+
+			resp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: test.importId}, &resp)
+			if resp.Diagnostics.HasError() != (test.wantId == "") {
+				t.Fatalf("expected error %t, got: %v", test.wantId == "", resp.Diagnostics)
+			}
+			if clusterId := stringAttribute(t, resp.State, "cluster_id"); clusterId != test.wantClusterId {
+				t.Errorf("expected the cluster ID %q, got %q", test.wantClusterId, clusterId)
+			}
+			if id := stringAttribute(t, resp.State, "id"); id != test.wantId {
+				t.Errorf("expected the ID %q, got %q", test.wantId, id)
+			}
+		})
+	}
+}
+
+// configuredDataSource returns the data source of the type, configured with a client of the fake API.
+func configuredDataSource(t *testing.T, api *fakeApi, typeName string) datasource.DataSource {
+	t.Helper()
+	ctx := context.Background()
+	for _, newDataSource := range mds.New().DataSources(ctx) {
+		d := newDataSource()
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "vmds"}, &metadata)
+		if metadata.TypeName != typeName {
+			continue
+		}
+		var configure datasource.ConfigureResponse
+		d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: api.client(t)}, &configure)
+		return d
+	}
+	t.Fatalf("no data source of type %s", typeName)
+	return nil
+}
+
+func TestClusterBackupsDataSource(t *testing.T) {
+	api := newFakeApi(t)
+	var backupType string
+	api.handle(http.MethodGet, clusterBackupsPath, func(r *http.Request, _ map[string]interface{}) (int, interface{}) {
+		backupType = r.URL.Query().Get("backupType")
+		scheduled := backupObject("backup-2", "COMPLETED")
+		scheduled["backupType"] = "SCHEDULED"
+		return http.StatusOK, map[string]interface{}{
+			"_embedded": map[string]interface{}{"mdsBackupDTOes": []interface{}{backupObject("backup-1", "FAILED"), scheduled}},
+			"page":      map[string]int{"number": 0, "size": 100, "totalElements": 2, "totalPages": 1},
+		}
+	})
+	d := configuredDataSource(t, api, "vmds_cluster_backups")
+	ctx := context.Background()
+	var schema datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+	// the config is built as a state, which can be set attribute by attribute
+	config := tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)}
+	resp := datasource.ReadResponse{State: config}
+	resp.Diagnostics.Append(config.SetAttribute(ctx, path.Root("cluster_id"), "cluster-1")...)
+	resp.Diagnostics.Append(config.SetAttribute(ctx, path.Root("backup_type"), "SCHEDULED")...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to configure the data source: %v", resp.Diagnostics)
+	}
+
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config(config)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if backupType != "SCHEDULED" {
+		t.Errorf("expected the backups fetched by type SCHEDULED, got %q", backupType)
+	}
+
+	var backups []struct {
+		ID      types.String `tfsdk:"id"`
+		Name    types.String `tfsdk:"name"`
+		Type    types.String `tfsdk:"type"`
+		Version types.String `tfsdk:"version"`
+		Size    types.String `tfsdk:"size"`
+		Status  types.String `tfsdk:"status"`
+		Created types.String `tfsdk:"created"`
+	}
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("backups"), &backups)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to read the backups: %v", resp.Diagnostics)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %d", len(backups))
+	}
+	first, second := backups[0], backups[1]
+	if first.ID.ValueString() != "backup-1" || first.Status.ValueString() != "FAILED" || first.Size.ValueString() != "2 GB" ||
+		first.Created.ValueString() != "2025-01-01T00:00:00Z" || first.Version.ValueString() != "15.4" {
+		t.Errorf("unexpected first backup: %+v", first)
+	}
+	if second.ID.ValueString() != "backup-2" || second.Type.ValueString() != "SCHEDULED" {
+		t.Errorf("unexpected second backup: %+v", second)
+	}
+}
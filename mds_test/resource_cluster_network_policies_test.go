@@ -0,0 +1,106 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// networkPolicyIds returns the network_policy_ids saved in state, sorted.
+func networkPolicyIds(t *testing.T, state tfsdk.State) []string {
+	t.Helper()
+	var ids []string
+	if diags := state.GetAttribute(context.Background(), path.Root("network_policy_ids"), &ids); diags.HasError() {
+		t.Fatalf("unable to read network_policy_ids: %v", diags)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestClusterNetworkPoliciesRead(t *testing.T) {
+	api := newFakeApi(t)
+	putCluster(api, "READY", "XX-SMALL")
+	var query map[string]string
+	api.handle(http.MethodGet, "/api/customermetadata/mdspolicies", func(r *http.Request, _ map[string]interface{}) (int, interface{}) {
+		query = map[string]string{"serviceType": r.URL.Query().Get("serviceType"), "resourceId": r.URL.Query().Get("resourceId")}
+		return http.StatusOK, map[string]interface{}{
+			"_embedded": map[string]interface{}{"mdsPolicyDTOes": []map[string]string{
+				{"id": "policy-2", "name": "office", "serviceType": "NETWORK"},
+				{"id": "policy-3", "name": "vpn", "serviceType": "NETWORK"},
+			}},
+			"page": map[string]int{"number": 0, "size": 100, "totalElements": 2, "totalPages": 1},
+		}
+	})
+	r := configuredResource(t, api, "vmds_cluster")
+	state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if want := map[string]string{"serviceType": "NETWORK", "resourceId": "cluster-1"}; !reflect.DeepEqual(query, want) {
+		t.Errorf("expected the policies queried with %v, got %v", want, query)
+	}
+	// policies associated outside of Terraform show up as drift
+	if ids := networkPolicyIds(t, resp.State); !reflect.DeepEqual(ids, []string{"policy-2", "policy-3"}) {
+		t.Errorf("expected the associated policies refreshed, got %v", ids)
+	}
+}
+
+func TestClusterNetworkPoliciesUpdate(t *testing.T) {
+	tests := map[string]struct {
+		policyIds []string
+		wantPatch bool
+	}{
+		"changed":   {policyIds: []string{"policy-1", "policy-2"}, wantPatch: true},
+		"unchanged": {policyIds: []string{"policy-1"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			putCluster(api, "READY", "XX-SMALL")
+			var patchBody map[string]interface{}
+			api.handle(http.MethodPatch, resizedClusterPath+"/networkpolicy", func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+				patchBody = body
+				return http.StatusOK, map[string]interface{}{}
+			})
+			api.handle(http.MethodPatch, resizedClusterPath, func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, api.objects[resizedClusterPath]
+			})
+			r := configuredResource(t, api, "vmds_cluster")
+			state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+			attributes := clusterAttributes("XX-SMALL")
+			attributes["network_policy_ids"] = test.policyIds
+			plan := planOf(t, r, attributes)
+
+			resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.Update(context.Background(), fwresource.UpdateRequest{State: state, Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if (patchBody != nil) != test.wantPatch {
+				t.Fatalf("expected the network policies updated %t, got %v", test.wantPatch, patchBody)
+			}
+			if test.wantPatch {
+				var patched []string
+				for _, id := range patchBody["networkPolicyIds"].([]interface{}) {
+					patched = append(patched, id.(string))
+				}
+				sort.Strings(patched)
+				if !reflect.DeepEqual(patched, test.policyIds) {
+					t.Errorf("expected the policies %v submitted, got %v", test.policyIds, patched)
+				}
+			}
+			if ids := networkPolicyIds(t, resp.State); !reflect.DeepEqual(ids, test.policyIds) {
+				t.Errorf("expected the planned policies saved, got %v", ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"net/http"
+	"testing"
+)
+
+func TestClusterReadPlacement(t *testing.T) {
+	tests := map[string]struct {
+		sent          map[string]interface{}
+		wantDedicated bool
+		wantShared    bool
+	}{
+		"not sent":  {wantDedicated: true},
+		"sent":      {sent: map[string]interface{}{"dedicated": false, "shared": true}, wantShared: true},
+		"some sent": {sent: map[string]interface{}{"shared": false}, wantDedicated: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			putCluster(api, "READY", "XX-SMALL")
+			for field, value := range test.sent {
+				api.objects[resizedClusterPath][field] = value
+			}
+			api.handle(http.MethodGet, "/api/customermetadata/mdspolicies", func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, map[string]interface{}{
+					"_embedded": map[string]interface{}{"mdsPolicyDTOes": []map[string]string{{"id": "policy-1", "name": "office", "serviceType": "NETWORK"}}},
+					"page":      map[string]int{"number": 0, "size": 100, "totalElements": 1, "totalPages": 1},
+				}
+			})
+			r := configuredResource(t, api, "vmds_cluster")
+			attributes := clusterAttributes("XX-SMALL")
+			attributes["dedicated"], attributes["shared"] = true, false
+			state := tfsdk.State(planOf(t, r, attributes))
+
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			var dedicated, shared types.Bool
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("dedicated"), &dedicated)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("shared"), &shared)...)
+			if dedicated.ValueBool() != test.wantDedicated || shared.ValueBool() != test.wantShared {
+				t.Errorf("expected dedicated %t and shared %t, got %s and %s", test.wantDedicated, test.wantShared, dedicated, shared)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const resizedClusterPath = "/api/controller/mdsclusters/cluster-1"
+
+// clusterAttributes are the attributes of cluster-1 in state and plan, besides its instance size.
+func clusterAttributes(instanceSize string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 "cluster-1",
+		"name":               "test-cluster",
+		"service_type":       "POSTGRES",
+		"cloud_provider":     "aws",
+		"instance_size":      instanceSize,
+		"region":             "eu-west-1",
+		"version":            "15.4",
+		"network_policy_ids": []string{"policy-1"},
+	}
+}
+
+// putCluster stores cluster-1 as MDS returns it.
+func putCluster(api *fakeApi, status string, instanceSize string) {
+	api.put(resizedClusterPath, map[string]interface{}{
+		"id":           "cluster-1",
+		"name":         "test-cluster",
+		"serviceType":  "POSTGRES",
+		"provider":     "aws",
+		"instanceSize": instanceSize,
+		"region":       "eu-west-1",
+		"version":      "15.4",
+		"status":       status,
+		"tags":         []string{},
+		"metadata":     map[string]interface{}{"metricsEnpoints": []string{}},
+		"lastUpdated":  "2025-01-01T00:00:00Z",
+		"created":      "2025-01-01T00:00:00Z",
+		"dataPlaneId":  "dataplane-1",
+		"orgId":        "test-org",
+	})
+}
+
+func TestClusterResize(t *testing.T) {
+	tests := map[string]struct {
+		taskStatus    string
+		clusterStatus string
+		wantErr       string
+	}{
+		"resized":              {taskStatus: "SUCCESS", clusterStatus: "READY"},
+		"resize failed":        {taskStatus: "FAILED", clusterStatus: "READY", wantErr: "Resize of cluster [cluster-1] did not complete"},
+		"cluster failed after": {taskStatus: "SUCCESS", clusterStatus: "FAILED", wantErr: "did not become ready after resize"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			putCluster(api, "READY", "XX-SMALL")
+			var resizeBody map[string]interface{}
+			api.handle(http.MethodPost, resizedClusterPath+"/resize", func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+				resizeBody = body
+				if test.taskStatus == "SUCCESS" {
+					api.objects[resizedClusterPath]["instanceSize"] = body["instanceSize"]
+				}
+				api.objects[resizedClusterPath]["status"] = test.clusterStatus
+				return http.StatusOK, api.task(test.taskStatus, "cluster-1")
+			})
+			api.handle(http.MethodPatch, resizedClusterPath, func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, api.objects[resizedClusterPath]
+			})
+			r := configuredResource(t, api, "vmds_cluster")
+			state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+			plan := planOf(t, r, clusterAttributes("SMALL"))
+
+			resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.Update(context.Background(), fwresource.UpdateRequest{State: state, Plan: plan}, &resp)
+			if resizeBody["instanceSize"] != "SMALL" {
+				t.Errorf("expected a resize to SMALL, got %v", resizeBody)
+			}
+			if test.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.wantErr) {
+					t.Fatalf("expected the error %q, got: %v", test.wantErr, resp.Diagnostics)
+				}
+				// the prior state is kept, so the next apply submits the resize again
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected no new state")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			var instanceSize types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(context.Background(), path.Root("instance_size"), &instanceSize)...)
+			if instanceSize.ValueString() != "SMALL" {
+				t.Errorf("expected the new instance size saved, got %s", instanceSize)
+			}
+		})
+	}
+}
+
+func TestClusterInstanceSizeValidation(t *testing.T) {
+	tests := map[string]struct {
+		instanceSize string
+		unavailable  bool
+		wantErr      bool
+		wantWarning  bool
+	}{
+		"offered":              {instanceSize: "SMALL"},
+		"not offered":          {instanceSize: "HUGE", wantErr: true},
+		"unchanged":            {instanceSize: "XX-SMALL"},
+		"instance types error": {instanceSize: "SMALL", unavailable: true, wantWarning: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			api.handle(http.MethodGet, "/api/controller/mdsservices/instanceTypes", func(r *http.Request, _ map[string]interface{}) (int, interface{}) {
+				if test.unavailable || r.URL.Query().Get("serviceType") != "POSTGRES" {
+					return http.StatusBadRequest, map[string]string{"errorMsg": "unavailable"}
+				}
+				return http.StatusOK, map[string]interface{}{"instanceTypes": []map[string]string{
+					{"serviceType": "POSTGRES", "instanceSize": "XX-SMALL"},
+					{"serviceType": "POSTGRES", "instanceSize": "SMALL"},
+				}}
+			})
+			r := configuredResource(t, api, "vmds_cluster")
+			state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+			plan := planOf(t, r, clusterAttributes(test.instanceSize))
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				State:  state,
+				Plan:   plan,
+				Config: tfsdk.Config(plan),
+			}, &resp)
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("expected error %t, got: %v", test.wantErr, resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != test.wantWarning {
+				t.Errorf("expected warning %t, got: %v", test.wantWarning, resp.Diagnostics)
+			}
+			if requested := api.requested(http.MethodGet, "/api/controller/mdsservices/instanceTypes"); requested == (name == "unchanged") {
+				t.Errorf("expected the instance types fetched only for a changed size, fetched: %t", requested)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"testing"
+)
+
+const (
+	upgradePath        = "/api/upgradeservice/upgrade"
+	upgradeTargetsPath = "/api/upgradeservice/upgrade/targets"
+)
+
+// upgradedPaths are the paths of the objects upgraded, by the type of the upgrade request.
+var upgradedPaths = map[string]string{
+	"SERVICE":    "/api/controller/mdsclusters/",
+	"DATA_PLANE": "/api/infra-connector/k8s-cluster/",
+}
+
+// handleUpgrades offers the target versions to every cluster and dataplane, and upgrades one at once to the version
+// submitted. The versions submitted are returned by ID.
+func handleUpgrades(api *fakeApi, targetVersions []string) map[string]string {
+	submitted := map[string]string{}
+	api.handle(http.MethodGet, upgradeTargetsPath, func(r *http.Request, _ map[string]interface{}) (int, interface{}) {
+		if targetVersions == nil {
+			return http.StatusBadRequest, map[string]string{"errorMsg": "unavailable"}
+		}
+		return http.StatusOK, map[string]interface{}{"id": r.URL.Query().Get("id"), "targetVersions": targetVersions}
+	})
+	api.handle(http.MethodPost, upgradePath, func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+		id, targetVersion := body["id"].(string), body["targetVersion"].(string)
+		submitted[id] = targetVersion
+		api.objects[upgradedPaths[body["requestType"].(string)]+id]["version"] = targetVersion
+		return http.StatusOK, map[string]bool{"success": true}
+	})
+	return submitted
+}
+
+func TestClusterUpgradeResolvesVersion(t *testing.T) {
+	tests := map[string]struct {
+		version        string
+		targetVersions []string
+		wantSubmitted  string
+	}{
+		"offered":          {version: "16.1", targetVersions: []string{"16.1", "16.3"}, wantSubmitted: "16.1"},
+		"major":            {version: "16", targetVersions: []string{"15.6", "16.1", "16.3"}, wantSubmitted: "16.3"},
+		"targets failed":   {version: "16", wantSubmitted: "16"},
+		"prefixed offered": {version: "v16.1", targetVersions: []string{"16.1"}, wantSubmitted: "16.1"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			putCluster(api, "READY", "XX-SMALL")
+			submitted := handleUpgrades(api, test.targetVersions)
+			api.handle(http.MethodPatch, resizedClusterPath, func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+				return http.StatusOK, api.objects[resizedClusterPath]
+			})
+			r := configuredResource(t, api, "vmds_cluster")
+			state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+			attributes := clusterAttributes("XX-SMALL")
+			attributes["version"] = test.version
+			plan := planOf(t, r, attributes)
+
+			resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+			r.Update(context.Background(), fwresource.UpdateRequest{State: state, Plan: plan}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if submitted["cluster-1"] != test.wantSubmitted {
+				t.Errorf("expected the version %s submitted, got %q", test.wantSubmitted, submitted["cluster-1"])
+			}
+			// the version is kept as configured, so the next plan is empty
+			if version := stringAttribute(t, resp.State, "version"); version != test.version {
+				t.Errorf("expected the version %s saved, got %s", test.version, version)
+			}
+		})
+	}
+}
+
+func TestClusterUpgradeTargetValidation(t *testing.T) {
+	tests := map[string]struct {
+		version string
+		wantErr bool
+	}{
+		"offered":     {version: "16.1"},
+		"major":       {version: "16"},
+		"not offered": {version: "17", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := newFakeApi(t)
+			handleUpgrades(api, []string{"16.1", "16.3"})
+			r := configuredResource(t, api, "vmds_cluster")
+			state := tfsdk.State(planOf(t, r, clusterAttributes("XX-SMALL")))
+			attributes := clusterAttributes("XX-SMALL")
+			attributes["version"] = test.version
+			plan := planOf(t, r, attributes)
+
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				State:  state,
+				Plan:   plan,
+				Config: tfsdk.Config(plan),
+			}, &resp)
+			if resp.Diagnostics.HasError() != test.wantErr {
+				t.Errorf("expected error %t, got: %v", test.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestClusterFleetUpgradeResolvesVersion(t *testing.T) {
+	api := newFakeApi(t)
+	fleet := map[string]string{"cluster-1": "15.4", "cluster-2": "14.9"}
+	for id, version := range fleet {
+		api.put("/api/controller/mdsclusters/"+id, map[string]interface{}{
+			"id": id, "name": id, "serviceType": "POSTGRES", "version": version, "status": "READY", "tags": []string{"prod"},
+		})
+	}
+	api.handle(http.MethodGet, "/api/controller/mdsclusters", func(_ *http.Request, _ map[string]interface{}) (int, interface{}) {
+		return http.StatusOK, map[string]interface{}{
+			"_embedded": map[string]interface{}{"mdsClusterDTOes": []interface{}{
+				api.objects["/api/controller/mdsclusters/cluster-1"], api.objects["/api/controller/mdsclusters/cluster-2"],
+			}},
+			"page": map[string]int{"number": 0, "size": 100, "totalElements": 2, "totalPages": 1},
+		}
+	})
+	submitted := handleUpgrades(api, []string{"15.2", "15.4"})
+	r := configuredResource(t, api, "vmds_cluster_fleet_upgrade")
+	plan := planOf(t, r, map[string]interface{}{"service_type": "POSTGRES", "tags": []string{"prod"}, "target_version": "15"})
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	// cluster-1 at 15.4 is designated by 15 already
+	if len(submitted) != 1 || submitted["cluster-2"] != "15.4" {
+		t.Errorf("expected only cluster-2 upgraded to 15.4, got %v", submitted)
+	}
+	var clusters []struct {
+		ID              string  `tfsdk:"id"`
+		Name            string  `tfsdk:"name"`
+		PreviousVersion string  `tfsdk:"previous_version"`
+		Version         string  `tfsdk:"version"`
+		Result          string  `tfsdk:"result"`
+		Error           *string `tfsdk:"error"`
+	}
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("clusters"), &clusters); diags.HasError() {
+		t.Fatalf("unable to read the clusters: %v", diags)
+	}
+	for _, cluster := range clusters {
+		if cluster.Version != "15.4" {
+			t.Errorf("expected %s reported at 15.4, got %s (%s)", cluster.ID, cluster.Version, cluster.Result)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package mds_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"net/http"
+	"testing"
+)
+
+// planOf returns a plan of the resource with the attributes set, the others are null.
+func planOf(t *testing.T, r fwresource.Resource, attributes map[string]interface{}) tfsdk.Plan {
+	t.Helper()
+	ctx := context.Background()
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+	plan := tfsdk.Plan{
+		Schema: schema.Schema,
+		Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+	}
+	for name, value := range attributes {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unable to set %s: %v", name, diags)
+		}
+	}
+	return plan
+}
+
+// createdId returns the ID saved in state by Create, empty when nothing was saved.
+func createdId(t *testing.T, state tfsdk.State) string {
+	t.Helper()
+	if state.Raw.IsNull() {
+		return ""
+	}
+	var id types.String
+	if diags := state.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("unable to read the ID: %v", diags)
+	}
+	return id.ValueString()
+}
+
+var incompleteCreates = map[string]struct {
+	urlPath    string
+	attributes map[string]interface{}
+}{
+	"vmds_cluster": {
+		urlPath: "/api/controller/mdsclusters",
+		attributes: map[string]interface{}{
+			"name":               "test-cluster",
+			"service_type":       "POSTGRES",
+			"cloud_provider":     "aws",
+			"instance_size":      "XX-SMALL",
+			"region":             "eu-west-1",
+			"network_policy_ids": []string{"policy-1"},
+		},
+	},
+	"vmds_byoc_dataplane": {
+		urlPath: "/api/infra-connector/k8s-cluster",
+		attributes: map[string]interface{}{
+			"name":           "test-dataplane",
+			"account_id":     "account-1",
+			"certificate_id": "certificate-1",
+			"nodepool_type":  "regular",
+			"region":         "eu-west-1",
+		},
+	},
+}
+
+func TestResourcesCreateIncomplete(t *testing.T) {
+	tests := map[string]struct {
+		taskStatus string
+		// created tells whether MDS created the object, and with which status
+		created string
+		wantId  string
+	}{
+		"task failed after creating":  {taskStatus: "FAILED", created: "CREATING", wantId: "created-1"},
+		"task failed before creating": {taskStatus: "FAILED"},
+		"object failed":               {taskStatus: "SUCCESS", created: "FAILED", wantId: "created-1"},
+	}
+	for typeName, create := range incompleteCreates {
+		for name, test := range tests {
+			t.Run(typeName+"/"+name, func(t *testing.T) {
+				api := newFakeApi(t)
+				api.handle(http.MethodPost, create.urlPath, func(_ *http.Request, body map[string]interface{}) (int, interface{}) {
+					if test.created == "" {
+						return http.StatusOK, api.task(test.taskStatus, "")
+					}
+					body["id"], body["status"] = "created-1", test.created
+					api.objects[create.urlPath+"/created-1"] = body
+					return http.StatusOK, api.task(test.taskStatus, "created-1")
+				})
+				r := configuredResource(t, api, typeName)
+				plan := planOf(t, r, create.attributes)
+
+				resp := fwresource.CreateResponse{State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(context.Background()), nil)}}
+				r.Create(context.Background(), fwresource.CreateRequest{Plan: plan}, &resp)
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected the creation to fail")
+				}
+				// an object kept in state is tainted by Terraform, and replaced on the next apply
+				if id := createdId(t, resp.State); id != test.wantId {
+					t.Errorf("expected the ID %q saved in state, got %q: %v", test.wantId, id, resp.Diagnostics)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package mds_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/svc-bot-mds/terraform-provider-vmds/mds"
+	"net/http"
+	"testing"
+)
+
+// notFoundResources are the resources which forget objects deleted outside of Terraform,
+// by the path their objects are read and deleted at.
+var notFoundResources = map[string]string{
+	"vmds_cluster":         "/api/controller/mdsclusters/",
+	"vmds_byoc_dataplane":  "/api/infra-connector/k8s-cluster/",
+	"vmds_user":            "/api/customermetadata/mdsusers/",
+	"vmds_service_account": "/api/customermetadata/mdsusers/",
+	"vmds_policy":          "/api/customermetadata/mdspolicies/",
+	"vmds_network_policy":  "/api/customermetadata/mdspolicies/",
+	"vmds_cloud_account":   "/api/infra-connector/account/",
+}
+
+const notFoundCertificateConfig = `
+resource "vmds_certificate" "test" {
+  name            = "test-certificate"
+  domain_name     = "example.com"
+  provider_type   = "aws"
+  certificate     = "certificate"
+  certificate_ca  = "certificate-ca"
+  certificate_key = "certificate-key"
+}
+`
+
+func TestCertificateResourceDeletedOutOfBand(t *testing.T) {
+	api := newFakeApi(t)
+
+	// runs a terraform binary, so like the acceptance tests it is skipped unless TF_ACC is set
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + notFoundCertificateConfig,
+				Check:  resource.TestCheckResourceAttr("vmds_certificate.test", "id", "certificate-1"),
+			},
+			// deleted in the MDS console: the refresh drops it from state, and the plan creates it again
+			{
+				PreConfig:          func() { api.remove("/api/infra-connector/certificate/certificate-1") },
+				Config:             api.providerConfig() + notFoundCertificateConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestCertificateResourceDeleteNotFound(t *testing.T) {
+	api := newFakeApi(t)
+
+	// runs a terraform binary, so like the acceptance tests it is skipped unless TF_ACC is set
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if api.deleteCount() == 0 {
+				return fmt.Errorf("expected the certificate to be deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + notFoundCertificateConfig,
+			},
+			// already deleted by someone else when Terraform deletes it, which must not fail the destroy
+			{
+				PreConfig: func() { api.setDeletesNotFound(true) },
+				Config:    api.providerConfig() + notFoundCertificateConfig,
+				Destroy:   true,
+			},
+		},
+	})
+}
+
+// configuredResource returns the resource of the type, configured with a client of the fake API.
+func configuredResource(t *testing.T, api *fakeApi, typeName string) fwresource.Resource {
+	t.Helper()
+	ctx := context.Background()
+	for _, newResource := range mds.New().Resources(ctx) {
+		r := newResource()
+		var metadata fwresource.MetadataResponse
+		r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "vmds"}, &metadata)
+		if metadata.TypeName != typeName {
+			continue
+		}
+		var configure fwresource.ConfigureResponse
+		r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{ProviderData: api.client(t)}, &configure)
+		if configure.Diagnostics.HasError() {
+			t.Fatalf("unable to configure %s: %v", typeName, configure.Diagnostics)
+		}
+		return r
+	}
+	t.Fatalf("no resource of type %s", typeName)
+	return nil
+}
+
+// stateOf returns a state of the resource with only its ID set, as left by an earlier apply.
+func stateOf(t *testing.T, r fwresource.Resource, id string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+	state := tfsdk.State{
+		Schema: schema.Schema,
+		Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.SetAttribute(ctx, path.Root("id"), id); diags.HasError() {
+		t.Fatalf("unable to set the ID: %v", diags)
+	}
+	return state
+}
+
+func TestResourcesReadNotFound(t *testing.T) {
+	for typeName, urlPath := range notFoundResources {
+		t.Run(typeName, func(t *testing.T) {
+			api := newFakeApi(t)
+			r := configuredResource(t, api, typeName)
+			state := stateOf(t, r, "deleted-1")
+
+			// deleted in the MDS console: the refresh drops it from state, so the plan creates it again
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+			}
+			if !api.requested(http.MethodGet, urlPath+"deleted-1") {
+				t.Fatalf("expected %s to be read from %s", typeName, urlPath+"deleted-1")
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Errorf("expected %s to be removed from state", typeName)
+			}
+		})
+	}
+}
+
+func TestResourcesDeleteNotFound(t *testing.T) {
+	for typeName, urlPath := range notFoundResources {
+		t.Run(typeName, func(t *testing.T) {
+			api := newFakeApi(t)
+			r := configuredResource(t, api, typeName)
+			state := stateOf(t, r, "deleted-1")
+
+			// already deleted by someone else when Terraform deletes it, which must not fail the destroy
+			var resp fwresource.DeleteResponse
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected no error, got: %v", resp.Diagnostics)
+			}
+			if !api.requested(http.MethodDelete, urlPath+"deleted-1") {
+				t.Errorf("expected %s to be deleted at %s", typeName, urlPath+"deleted-1")
+			}
+		})
+	}
+}